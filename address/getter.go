@@ -2,10 +2,7 @@ package address
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
-	"net/url"
 
 	"github.com/cpurta/go-raiden-client/config"
 	"github.com/cpurta/go-raiden-client/util"
@@ -43,32 +40,12 @@ type defaultGetter struct {
 // in the Lister config.
 func (lister *defaultGetter) Get(ctx context.Context) (common.Address, error) {
 	var (
-		err     error
-		address = common.Address{}
-
-		requestURL      *url.URL
-		request         *http.Request
-		response        *http.Response
+		err             error
+		address         = common.Address{}
 		addressResponse = &addressResponse{}
 	)
 
-	if requestURL, err = lister.getRequestURL(); err != nil {
-		return address, err
-	}
-
-	if request, err = http.NewRequest("GET", requestURL.String(), nil); err != nil {
-		return address, err
-	}
-
-	request = request.WithContext(ctx)
-
-	if response, err = lister.baseClient.HTTPClient.Do(request); err != nil {
-		return address, err
-	}
-
-	defer response.Body.Close()
-
-	if err = json.NewDecoder(response.Body).Decode(&addressResponse); err != nil {
+	if err = lister.baseClient.Do(ctx, "GET", []string{"address"}, nil, addressResponse); err != nil {
 		return address, err
 	}
 
@@ -76,17 +53,3 @@ func (lister *defaultGetter) Get(ctx context.Context) (common.Address, error) {
 
 	return address, nil
 }
-
-func (lister *defaultGetter) getRequestURL() (*url.URL, error) {
-	var (
-		err        error
-		endpoint   = fmt.Sprintf("%s/api/%s/address", lister.baseClient.Config.Host, lister.baseClient.Config.APIVersion)
-		requestURL *url.URL
-	)
-
-	if requestURL, err = url.Parse(endpoint); err != nil {
-		return nil, err
-	}
-
-	return requestURL, nil
-}
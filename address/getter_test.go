@@ -2,13 +2,13 @@ package address
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"testing"
 
 	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -83,7 +83,7 @@ func TestNewGetter(t *testing.T) {
 					),
 				)
 			},
-			expectedError:   errors.New("EOF"),
+			expectedError:   &util.APIError{StatusCode: http.StatusInternalServerError, Method: "GET", URL: "http://localhost:5001/api/v1/address"},
 			expectedAddress: common.Address{},
 		},
 		testcase{
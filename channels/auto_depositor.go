@@ -0,0 +1,187 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TopUpPolicy tells an AutoDepositor to top up the open channels of a token
+// network whose balance fell below Threshold until their balance reaches Cap.
+type TopUpPolicy struct {
+	TokenAddress common.Address
+	Threshold    *util.Amount
+	Cap          *util.Amount
+}
+
+// AutoDepositor is an interface to keep the balance of channels above the
+// threshold of their token's TopUpPolicy, either once or continuously.
+type AutoDepositor interface {
+	TopUp(ctx context.Context) ([]*Channel, error)
+	Run(ctx context.Context, onTopUp func(*Channel), onError func(error)) error
+}
+
+var _ AutoDepositor = &defaultAutoDepositor{}
+
+// NewAutoDepositor will create a default auto depositor that applies the policies
+// to the channels listed by the lister or reported by the watcher and deposits
+// with the adder. Invalid policies are reported by TopUp and Run.
+func NewAutoDepositor(lister Lister, watcher Watcher, adder DepositAdder, policies []*TopUpPolicy) AutoDepositor {
+	var (
+		autoDepositor = &defaultAutoDepositor{
+			lister:   lister,
+			watcher:  watcher,
+			adder:    adder,
+			policies: make(map[common.Address]*TopUpPolicy, len(policies)),
+		}
+	)
+
+	for _, policy := range policies {
+		if _, ok := autoDepositor.policies[policy.TokenAddress]; ok {
+			autoDepositor.err = fmt.Errorf("token %s has more than one top-up policy", policy.TokenAddress.Hex())
+			break
+		}
+
+		if autoDepositor.err = validatePolicy(policy); autoDepositor.err != nil {
+			break
+		}
+
+		autoDepositor.policies[policy.TokenAddress] = policy
+	}
+
+	return autoDepositor
+}
+
+type defaultAutoDepositor struct {
+	lister   Lister
+	watcher  Watcher
+	adder    DepositAdder
+	policies map[common.Address]*TopUpPolicy
+	err      error
+}
+
+// TopUp will list the channels of the node once and top up every open channel
+// whose balance is below the threshold of its token's policy. Channels that could
+// not be topped up do not stop the others from being topped up; their errors are
+// returned together along with the topped up channels.
+func (autoDepositor *defaultAutoDepositor) TopUp(ctx context.Context) ([]*Channel, error) {
+	var (
+		err       error
+		channels  []*Channel
+		toppedUp  = make([]*Channel, 0)
+		failures  = make([]string, 0)
+		channel   *Channel
+		deposited bool
+	)
+
+	if autoDepositor.err != nil {
+		return nil, autoDepositor.err
+	}
+
+	if channels, err = autoDepositor.lister.List(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, current := range channels {
+		if channel, deposited, err = autoDepositor.topUp(ctx, current); err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+
+		if deposited {
+			toppedUp = append(toppedUp, channel)
+		}
+	}
+
+	if len(failures) > 0 {
+		return toppedUp, fmt.Errorf("unable to top up %d channel(s): %s", len(failures), strings.Join(failures, "; "))
+	}
+
+	return toppedUp, nil
+}
+
+// Run will top up the channels of the node once and then watch them, topping up
+// every channel whose balance falls below its threshold. onTopUp, if it is not
+// nil, is called with every channel after its deposit and onError, if it is not
+// nil, for every error while watching or depositing. It blocks until the context is done and returns
+// the context's error.
+func (autoDepositor *defaultAutoDepositor) Run(ctx context.Context, onTopUp func(*Channel), onError func(error)) error {
+	if autoDepositor.err != nil {
+		return autoDepositor.err
+	}
+
+	notify := func(channel *Channel) {
+		if onTopUp != nil {
+			onTopUp(channel)
+		}
+	}
+
+	report := func(err error) {
+		if onError != nil {
+			onError(err)
+		}
+	}
+
+	channels, err := autoDepositor.TopUp(ctx)
+
+	for _, channel := range channels {
+		notify(channel)
+	}
+
+	if err != nil {
+		report(err)
+	}
+
+	return autoDepositor.watcher.WatchFunc(ctx, common.Address{}, func(change *Change) {
+		if change.Type != ChangeOpened && change.Type != ChangeWithdrawn && change.Type != ChangeBalanceChanged {
+			return
+		}
+
+		channel, deposited, err := autoDepositor.topUp(ctx, change.Channel)
+
+		if err != nil {
+			report(err)
+			return
+		}
+
+		if deposited {
+			notify(channel)
+		}
+	}, report)
+}
+
+// topUp deposits into the channel if it is open and its balance is below the
+// threshold of its token's policy. The deposit is computed by the adder from the
+// channel as read right before depositing, so the listed balance, which may be
+// outdated, only decides whether to top up and the cap is never exceeded. It
+// returns whether the channel was topped up.
+func (autoDepositor *defaultAutoDepositor) topUp(ctx context.Context, channel *Channel) (*Channel, bool, error) {
+	policy, ok := autoDepositor.policies[channel.TokenAddress]
+
+	if !ok || channel.State != StateOpened || channel.Balance.Cmp(policy.Threshold) >= 0 {
+		return channel, false, nil
+	}
+
+	toppedUp, deposited, err := autoDepositor.adder.DepositUpTo(ctx, channel.TokenAddress, channel.PartnerAddress, policy.Cap)
+
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to top up channel %d with partner %s: %s", channel.ChannelIdentifier, channel.PartnerAddress.Hex(), err.Error())
+	}
+
+	return toppedUp, deposited, nil
+}
+
+func validatePolicy(policy *TopUpPolicy) error {
+	if policy.Threshold.Sign() <= 0 {
+		return fmt.Errorf("top-up threshold %s of token %s must be greater than zero", policy.Threshold, policy.TokenAddress.Hex())
+	}
+
+	if policy.Cap.Cmp(policy.Threshold) < 0 {
+		return fmt.Errorf("top-up cap %s of token %s must not be less than the threshold %s", policy.Cap, policy.TokenAddress.Hex(), policy.Threshold)
+	}
+
+	return nil
+}
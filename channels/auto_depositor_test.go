@@ -0,0 +1,277 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleAutoDepositor() {
+	var (
+		channelClient *Client
+		config        = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		tokenAddress = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359") // DAI Stablecoin
+		ctx, cancel  = context.WithCancel(context.Background())
+	)
+
+	defer cancel()
+
+	channelClient = NewClient(config, http.DefaultClient)
+
+	autoDepositor := NewAutoDepositor(channelClient, channelClient, channelClient, []*TopUpPolicy{
+		&TopUpPolicy{TokenAddress: tokenAddress, Threshold: util.NewAmount(1000), Cap: util.NewAmount(5000)},
+	})
+
+	onTopUp := func(channel *Channel) {
+		fmt.Printf("topped up channel %d to %s\n", channel.ChannelIdentifier, channel.TotalDeposit)
+	}
+
+	onError := func(err error) {
+		fmt.Println("unable to top up channels:", err)
+	}
+
+	if err := autoDepositor.Run(ctx, onTopUp, onError); err != nil {
+		fmt.Println("stopped topping up channels:", err)
+	}
+}
+
+// fakeDepositAdder records deposits by the order they were made in. The balances
+// of channels by partner are the balances read right before depositing.
+type fakeDepositAdder struct {
+	mutex    sync.Mutex
+	deposits map[int64]*util.Amount
+	balances map[common.Address]*util.Amount
+	err      error
+}
+
+func (adder *fakeDepositAdder) AddDeposit(ctx context.Context, tokenAddress, partnerAddress common.Address, delta *util.Amount) (*Channel, error) {
+	adder.mutex.Lock()
+	defer adder.mutex.Unlock()
+
+	if adder.err != nil {
+		return nil, adder.err
+	}
+
+	identifier := int64(len(adder.deposits) + 1)
+	adder.deposits[identifier] = delta
+
+	return &Channel{ChannelIdentifier: identifier, TokenAddress: tokenAddress, PartnerAddress: partnerAddress, TotalDeposit: delta}, nil
+}
+
+func (adder *fakeDepositAdder) DepositUpTo(ctx context.Context, tokenAddress, partnerAddress common.Address, balance *util.Amount) (*Channel, bool, error) {
+	var (
+		current = util.NewAmount(0)
+	)
+
+	adder.mutex.Lock()
+	defer adder.mutex.Unlock()
+
+	if adder.err != nil {
+		return nil, false, adder.err
+	}
+
+	if adder.balances[partnerAddress] != nil {
+		current = adder.balances[partnerAddress]
+	}
+
+	if current.Cmp(balance) >= 0 {
+		return &Channel{TokenAddress: tokenAddress, PartnerAddress: partnerAddress, Balance: current}, false, nil
+	}
+
+	identifier := int64(len(adder.deposits) + 1)
+	adder.deposits[identifier] = balance.Sub(current)
+
+	return &Channel{ChannelIdentifier: identifier, TokenAddress: tokenAddress, PartnerAddress: partnerAddress, Balance: balance}, true, nil
+}
+
+func TestAutoDepositorTopUp(t *testing.T) {
+	var (
+		tokenAddress = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+		otherToken   = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359")
+		partner1     = "0x61C808D82A3Ac53231750daDc13c777b59310bD9"
+		partner2     = "0x2a65Aca4D5fC5B5C859090a6c34d164135398226"
+		policy       = &TopUpPolicy{TokenAddress: tokenAddress, Threshold: util.NewAmount(100), Cap: util.NewAmount(500)}
+	)
+
+	otherChannel := testChannel(3, partner2, 0, 1000, 0, StateOpened)
+	otherChannel.TokenAddress = otherToken
+
+	type testcase struct {
+		name             string
+		channels         []*Channel
+		policies         []*TopUpPolicy
+		balances         map[common.Address]*util.Amount
+		depositErr       error
+		expectedToppedUp int
+		expectedDeposits map[int64]*util.Amount
+		expectedError    error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "tops up channels below the threshold",
+			channels: []*Channel{
+				testChannel(1, partner1, 40, 1000, 0, StateOpened),
+				testChannel(2, partner2, 100, 1000, 0, StateOpened),
+				otherChannel,
+			},
+			policies:         []*TopUpPolicy{policy},
+			balances:         map[common.Address]*util.Amount{common.HexToAddress(partner1): util.NewAmount(40)},
+			expectedDeposits: map[int64]*util.Amount{1: util.NewAmount(460)},
+			expectedToppedUp: 1,
+		},
+		testcase{
+			name: "deposit is computed from the current balance",
+			channels: []*Channel{
+				testChannel(1, partner1, 40, 1000, 0, StateOpened),
+			},
+			policies:         []*TopUpPolicy{policy},
+			balances:         map[common.Address]*util.Amount{common.HexToAddress(partner1): util.NewAmount(450)},
+			expectedDeposits: map[int64]*util.Amount{1: util.NewAmount(50)},
+			expectedToppedUp: 1,
+		},
+		testcase{
+			name: "channel topped up in the meantime",
+			channels: []*Channel{
+				testChannel(1, partner1, 40, 1000, 0, StateOpened),
+			},
+			policies:         []*TopUpPolicy{policy},
+			balances:         map[common.Address]*util.Amount{common.HexToAddress(partner1): util.NewAmount(600)},
+			expectedDeposits: map[int64]*util.Amount{},
+		},
+		testcase{
+			name: "skips channels that are not open",
+			channels: []*Channel{
+				testChannel(1, partner1, 0, 1000, 0, StateClosed),
+			},
+			policies:         []*TopUpPolicy{policy},
+			expectedDeposits: map[int64]*util.Amount{},
+		},
+		testcase{
+			name: "deposit failed",
+			channels: []*Channel{
+				testChannel(20, partner1, 0, 1000, 0, StateOpened),
+			},
+			policies:         []*TopUpPolicy{policy},
+			depositErr:       errors.New("insufficient token balance"),
+			expectedDeposits: map[int64]*util.Amount{},
+			expectedError:    errors.New("unable to top up 1 channel(s): unable to top up channel 20 with partner 0x61C808D82A3Ac53231750daDc13c777b59310bD9: insufficient token balance"),
+		},
+		testcase{
+			name:          "cap below threshold",
+			policies:      []*TopUpPolicy{&TopUpPolicy{TokenAddress: tokenAddress, Threshold: util.NewAmount(100), Cap: util.NewAmount(50)}},
+			expectedError: errors.New("top-up cap 50 of token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8 must not be less than the threshold 100"),
+		},
+		testcase{
+			name:          "threshold not positive",
+			policies:      []*TopUpPolicy{&TopUpPolicy{TokenAddress: tokenAddress, Threshold: util.NewAmount(0), Cap: util.NewAmount(50)}},
+			expectedError: errors.New("top-up threshold 0 of token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8 must be greater than zero"),
+		},
+		testcase{
+			name:          "duplicate policy",
+			policies:      []*TopUpPolicy{policy, policy},
+			expectedError: errors.New("token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8 has more than one top-up policy"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				lister        = &scriptedLister{results: []scriptedResult{scriptedResult{channels: tc.channels}}}
+				adder         = &fakeDepositAdder{deposits: make(map[int64]*util.Amount), balances: tc.balances, err: tc.depositErr}
+				autoDepositor = NewAutoDepositor(lister, NewWatcher(lister, nil), adder, tc.policies)
+			)
+
+			toppedUp, err := autoDepositor.TopUp(context.Background())
+
+			assert.Len(t, toppedUp, tc.expectedToppedUp)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tc.expectedDeposits != nil {
+				assert.Equal(t, tc.expectedDeposits, adder.deposits)
+			}
+		})
+	}
+}
+
+func TestAutoDepositorRun(t *testing.T) {
+	var (
+		tokenAddress = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+		partner      = "0x61C808D82A3Ac53231750daDc13c777b59310bD9"
+
+		lister = &scriptedLister{
+			results: []scriptedResult{
+				scriptedResult{channels: []*Channel{testChannel(1, partner, 200, 1000, 0, StateOpened)}},
+				scriptedResult{channels: []*Channel{testChannel(1, partner, 200, 1000, 0, StateOpened)}},
+				scriptedResult{channels: []*Channel{testChannel(1, partner, 30, 1000, 0, StateOpened)}},
+			},
+		}
+		adder         = &fakeDepositAdder{deposits: make(map[int64]*util.Amount), balances: map[common.Address]*util.Amount{common.HexToAddress(partner): util.NewAmount(30)}}
+		watcher       = NewWatcher(lister, &WatcherOptions{PollInterval: time.Millisecond})
+		autoDepositor = NewAutoDepositor(lister, watcher, adder, []*TopUpPolicy{
+			&TopUpPolicy{TokenAddress: tokenAddress, Threshold: util.NewAmount(100), Cap: util.NewAmount(500)},
+		})
+		ctx, cancel = context.WithCancel(context.Background())
+		toppedUp    = make([]*Channel, 0)
+	)
+
+	err := autoDepositor.Run(ctx, func(channel *Channel) {
+		toppedUp = append(toppedUp, channel)
+		cancel()
+	}, nil)
+
+	assert.Equal(t, context.Canceled, err)
+	require.Len(t, toppedUp, 1)
+	assert.Equal(t, map[int64]*util.Amount{1: util.NewAmount(470)}, adder.deposits)
+}
+
+func TestAutoDepositorRunWithoutCallbacks(t *testing.T) {
+	var (
+		tokenAddress = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+		partner      = "0x61C808D82A3Ac53231750daDc13c777b59310bD9"
+
+		lister = &scriptedLister{
+			results: []scriptedResult{
+				scriptedResult{channels: []*Channel{testChannel(1, partner, 30, 1000, 0, StateOpened)}},
+				scriptedResult{channels: []*Channel{testChannel(1, partner, 30, 1000, 0, StateOpened)}},
+				scriptedResult{channels: []*Channel{testChannel(1, partner, 20, 1000, 0, StateOpened)}},
+			},
+		}
+		adder         = &fakeDepositAdder{deposits: make(map[int64]*util.Amount), balances: map[common.Address]*util.Amount{common.HexToAddress(partner): util.NewAmount(30)}}
+		watcher       = NewWatcher(lister, &WatcherOptions{PollInterval: time.Millisecond})
+		autoDepositor = NewAutoDepositor(lister, watcher, adder, []*TopUpPolicy{
+			&TopUpPolicy{TokenAddress: tokenAddress, Threshold: util.NewAmount(100), Cap: util.NewAmount(500)},
+		})
+		ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	)
+
+	defer cancel()
+
+	// channels are topped up initially and while watching without any callback
+	err := autoDepositor.Run(ctx, nil, nil)
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	adder.mutex.Lock()
+	defer adder.mutex.Unlock()
+
+	assert.Equal(t, map[int64]*util.Amount{1: util.NewAmount(470), 2: util.NewAmount(470)}, adder.deposits)
+}
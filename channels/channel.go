@@ -1,17 +1,21 @@
 package channels
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
 
 type channel struct {
-	TokenNetworkIdentifier string `json:"token_network_identifier"`
-	ChannelIdentifier      int64  `json:"channel_identifier"`
-	PartnerAddress         string `json:"partner_address"`
-	TokenAddress           string `json:"token_address"`
-	Balance                int64  `json:"balance"`
-	TotalDeposit           int64  `json:"total_deposit"`
-	State                  string `json:"state"`
-	SettleTimeout          int64  `json:"settle_timeout"`
-	RevealTimeout          int64  `json:"reveal_timeout"`
+	TokenNetworkIdentifier string       `json:"token_network_identifier"`
+	ChannelIdentifier      int64        `json:"channel_identifier"`
+	PartnerAddress         string       `json:"partner_address"`
+	TokenAddress           string       `json:"token_address"`
+	Balance                *util.Amount `json:"balance"`
+	TotalDeposit           *util.Amount `json:"total_deposit"`
+	TotalWithdraw          *util.Amount `json:"total_withdraw"`
+	State                  ChannelState `json:"state"`
+	SettleTimeout          int64        `json:"settle_timeout"`
+	RevealTimeout          int64        `json:"reveal_timeout"`
 }
 
 // Channel represents a payment channel between two ethereum addresses. This contains
@@ -21,9 +25,25 @@ type Channel struct {
 	ChannelIdentifier      int64
 	PartnerAddress         common.Address
 	TokenAddress           common.Address
-	Balance                int64
-	TotalDeposit           int64
-	State                  string
+	Balance                *util.Amount
+	TotalDeposit           *util.Amount
+	TotalWithdraw          *util.Amount
+	State                  ChannelState
 	SettleTimeout          int64
 	RevealTimeout          int64
 }
+
+func (channel *channel) toChannel() *Channel {
+	return &Channel{
+		TokenNetworkIdentifier: common.HexToAddress(channel.TokenNetworkIdentifier),
+		ChannelIdentifier:      channel.ChannelIdentifier,
+		PartnerAddress:         common.HexToAddress(channel.PartnerAddress),
+		TokenAddress:           common.HexToAddress(channel.TokenAddress),
+		Balance:                channel.Balance,
+		TotalDeposit:           channel.TotalDeposit,
+		TotalWithdraw:          channel.TotalWithdraw,
+		State:                  channel.State,
+		SettleTimeout:          channel.SettleTimeout,
+		RevealTimeout:          channel.RevealTimeout,
+	}
+}
@@ -10,16 +10,32 @@ var (
 	_ Opener            = &Client{}
 	_ Closer            = &Client{}
 	_ IncreaseDepositor = &Client{}
+	_ Withdrawer        = &Client{}
+	_ Lister            = &Client{}
+	_ Getter            = &Client{}
+	_ Watcher           = &Client{}
+	_ DepositAdder      = &Client{}
 )
 
 // NewClient creates a new client to all channel operations that can be performed
-// on a Raiden node. This includes Opening, Closing and Increasing the deposit of
-// a channel.
+// on a Raiden node. This includes Opening, Closing, Increasing the deposit of,
+// Adding to the deposit of, Withdrawing from, Listing, Getting and Watching
+// channels, as well as predicting mediation fees.
 func NewClient(config *config.Config, httpClient *http.Client) *Client {
+	var (
+		lister = NewLister(config, httpClient)
+	)
+
 	return &Client{
 		Opener:            NewOpener(config, httpClient),
 		Closer:            NewCloser(config, httpClient),
 		IncreaseDepositor: NewIncreaseDepositor(config, httpClient),
+		Withdrawer:        NewWithdrawer(config, httpClient),
+		Lister:            lister,
+		Getter:            NewGetter(config, httpClient),
+		Watcher:           NewWatcher(lister, nil),
+		DepositAdder:      NewDepositAdder(config, httpClient),
+		FeeSchedules:      NewFeeSchedules(),
 	}
 }
 
@@ -29,4 +45,14 @@ type Client struct {
 	Opener
 	Closer
 	IncreaseDepositor
+	Withdrawer
+	Lister
+	Getter
+	Watcher
+	DepositAdder
+
+	// FeeSchedules predicts the mediation fees of the node's channels. The node
+	// does not expose its fee schedules through its REST API, so they have to be
+	// set to mirror the fee flags the node was started with.
+	FeeSchedules *FeeSchedules
 }
@@ -2,11 +2,7 @@ package channels
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
-	"net/url"
-	"strings"
 
 	"github.com/cpurta/go-raiden-client/config"
 	"github.com/cpurta/go-raiden-client/util"
@@ -14,7 +10,7 @@ import (
 )
 
 type channelCloseRequest struct {
-	State string `json:"state"`
+	State ChannelState `json:"state"`
 }
 
 // Closer represents a generic interface to Close a Payment Channel given a token and
@@ -23,6 +19,8 @@ type Closer interface {
 	Close(ctx context.Context, tokenAddress, partnerAddress common.Address) (*Channel, error)
 }
 
+var _ Closer = &defaultCloser{}
+
 // NewCloser creates a new default Channel closer given a Raiden node configuration
 // and an http client.
 func NewCloser(config *config.Config, httpClient *http.Client) Closer {
@@ -31,75 +29,39 @@ func NewCloser(config *config.Config, httpClient *http.Client) Closer {
 			Config:     config,
 			HTTPClient: httpClient,
 		},
+		getter: NewGetter(config, httpClient),
 	}
 }
 
 type defaultCloser struct {
 	baseClient *util.BaseClient
+	getter     Getter
 }
 
 // Close will close a payment channel given a token address and a partner address.
+// The current channel is fetched first so that closing a channel that is not open
+// is rejected before reaching the node.
 func (closer *defaultCloser) Close(ctx context.Context, tokenAddress, partnerAddress common.Address) (*Channel, error) {
 	var (
-		err     error
-		channel = &channel{}
-
-		requestURL          *url.URL
-		request             *http.Request
-		response            *http.Response
-		requestBody         []byte
+		err                 error
+		current             *Channel
+		channel             = &channel{}
 		channelCloseRequest = &channelCloseRequest{
-			State: "closed",
+			State: StateClosed,
 		}
 	)
 
-	if requestURL, err = closer.getRequestURL(tokenAddress, partnerAddress); err != nil {
-		return nil, err
-	}
-
-	if requestBody, err = json.Marshal(channelCloseRequest); err != nil {
-		return nil, err
-	}
-
-	if request, err = http.NewRequest("PATCH", requestURL.String(), strings.NewReader(string(requestBody))); err != nil {
-		return nil, err
-	}
-
-	request = request.WithContext(ctx)
-
-	if response, err = closer.baseClient.HTTPClient.Do(request); err != nil {
+	if current, err = closer.getter.Get(ctx, tokenAddress, partnerAddress); err != nil {
 		return nil, err
 	}
 
-	defer response.Body.Close()
-
-	if err = json.NewDecoder(response.Body).Decode(&channel); err != nil {
+	if err = validateTransition(current, "close", current.State.CanClose()); err != nil {
 		return nil, err
 	}
 
-	return &Channel{
-		TokenNetworkIdentifier: common.HexToAddress(channel.TokenNetworkIdentifier),
-		ChannelIdentifier:      channel.ChannelIdentifier,
-		PartnerAddress:         common.HexToAddress(channel.PartnerAddress),
-		TokenAddress:           common.HexToAddress(channel.TokenAddress),
-		Balance:                channel.Balance,
-		TotalDeposit:           channel.TotalDeposit,
-		State:                  channel.State,
-		SettleTimeout:          channel.SettleTimeout,
-		RevealTimeout:          channel.RevealTimeout,
-	}, nil
-}
-
-func (closer *defaultCloser) getRequestURL(tokenAddress, partnerAddress common.Address) (*url.URL, error) {
-	var (
-		err        error
-		endpoint   = fmt.Sprintf("%s/api/%s/channels/%s/%s", closer.baseClient.Config.Host, closer.baseClient.Config.APIVersion, tokenAddress.Hex(), partnerAddress.Hex())
-		requestURL *url.URL
-	)
-
-	if requestURL, err = url.Parse(endpoint); err != nil {
+	if err = closer.baseClient.Do(ctx, "PATCH", []string{"channels", tokenAddress.Hex(), partnerAddress.Hex()}, channelCloseRequest, channel); err != nil {
 		return nil, err
 	}
 
-	return requestURL, nil
+	return channel.toChannel(), nil
 }
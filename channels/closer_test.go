@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -44,6 +45,8 @@ func TestCloser(t *testing.T) {
 			Host:       "http://localhost:5001",
 			APIVersion: "v1",
 		}
+		currentChannel = `{"token_network_identifier":"0xE5637F0103794C7e05469A9964E4563089a5E6f2","channel_identifier":20,"partner_address":"0x61C808D82A3Ac53231750daDc13c777b59310bD9","token_address":"0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8","balance":25000000,"total_deposit":35000000,"state":"opened","settle_timeout":500,"reveal_timeout":30}`
+		closedChannel  = `{"token_network_identifier":"0xE5637F0103794C7e05469A9964E4563089a5E6f2","channel_identifier":20,"partner_address":"0x61C808D82A3Ac53231750daDc13c777b59310bD9","token_address":"0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8","balance":25000000,"total_deposit":35000000,"state":"closed","settle_timeout":500,"reveal_timeout":30}`
 	)
 
 	if os.Getenv("USE_IPV4") != "" {
@@ -61,6 +64,15 @@ func TestCloser(t *testing.T) {
 		testcase{
 			name: "successfully closed payment channel",
 			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						currentChannel,
+					),
+				)
+
 				httpmock.RegisterResponder(
 					"PATCH",
 					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
@@ -76,8 +88,8 @@ func TestCloser(t *testing.T) {
 				ChannelIdentifier:      int64(20),
 				PartnerAddress:         common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9"),
 				TokenAddress:           common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"),
-				Balance:                int64(25000000),
-				TotalDeposit:           int64(35000000),
+				Balance:                util.NewAmount(25000000),
+				TotalDeposit:           util.NewAmount(35000000),
 				State:                  "closed",
 				SettleTimeout:          int64(500),
 				RevealTimeout:          int64(30),
@@ -86,6 +98,15 @@ func TestCloser(t *testing.T) {
 		testcase{
 			name: "unexpected 500 response",
 			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						currentChannel,
+					),
+				)
+
 				httpmock.RegisterResponder(
 					"PATCH",
 					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
@@ -95,15 +116,59 @@ func TestCloser(t *testing.T) {
 					),
 				)
 			},
-			expectedError:   errors.New("EOF"),
+			expectedError:   &util.APIError{StatusCode: http.StatusInternalServerError, Method: "PATCH", URL: "http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9"},
 			expectedChannel: &Channel{},
 		},
+		testcase{
+			name: "conflicting channel state",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						currentChannel,
+					),
+				)
+
+				httpmock.RegisterResponder(
+					"PATCH",
+					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					httpmock.NewStringResponder(
+						http.StatusConflict,
+						`{"errors":"Attempted to close an already closed channel"}`,
+					),
+				)
+			},
+			expectedError: &util.APIError{
+				StatusCode: http.StatusConflict,
+				Method:     "PATCH",
+				URL:        "http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+				Errors:     []string{"Attempted to close an already closed channel"},
+			},
+			expectedChannel: &Channel{},
+		},
+		testcase{
+			name: "channel is not open",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						closedChannel,
+					),
+				)
+			},
+			expectedError:   errors.New("unable to close channel 20 with partner 0x61C808D82A3Ac53231750daDc13c777b59310bD9: channel is closed"),
+			expectedChannel: nil,
+		},
 		testcase{
 			name: "unable to make http request",
 			prepHTTPMock: func() {
 				httpmock.Deactivate()
 			},
-			expectedError:   fmt.Errorf("Patch http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9: dial tcp %s:5001: connect: connection refused", localhostIP),
+			expectedError:   fmt.Errorf("Get http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9: dial tcp %s:5001: connect: connection refused", localhostIP),
 			expectedChannel: &Channel{},
 		},
 	}
@@ -0,0 +1,137 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultAddDepositAttempts is the number of times a DepositAdder tries to
+// deposit while other deposits into the same channel keep changing its total.
+const DefaultAddDepositAttempts = 3
+
+// DepositAdder represents a generic interface to add an amount to the deposit of a
+// Payment Channel, as opposed to IncreaseDepositor which sets the new total deposit.
+type DepositAdder interface {
+	AddDeposit(ctx context.Context, tokenAddress, partnerAddress common.Address, delta *util.Amount) (*Channel, error)
+	DepositUpTo(ctx context.Context, tokenAddress, partnerAddress common.Address, balance *util.Amount) (*Channel, bool, error)
+}
+
+var _ DepositAdder = &defaultDepositAdder{}
+
+// NewDepositAdder creates a new default Channel deposit adder given a Raiden node
+// configuration and an http client.
+func NewDepositAdder(config *config.Config, httpClient *http.Client) DepositAdder {
+	return &defaultDepositAdder{
+		getter:    NewGetter(config, httpClient),
+		depositor: NewIncreaseDepositor(config, httpClient),
+		locks:     make(map[watchKey]*sync.Mutex),
+	}
+}
+
+type defaultDepositAdder struct {
+	getter    Getter
+	depositor IncreaseDepositor
+
+	mutex sync.Mutex
+	locks map[watchKey]*sync.Mutex
+}
+
+// AddDeposit will add delta to the total deposit of a payment channel given a token
+// address and a partner address. Deposits made through the same adder into the same
+// channel are serialized, but other clients and adders may deposit between reading
+// the channel and sending the new total deposit. If the node rejects the new total
+// because such a deposit raised the total deposit to or above it, the channel is
+// read again and delta is added to the new total. If such a deposit raised the
+// total deposit by less than delta, the node accepts the new total and only the
+// difference is deposited by this call.
+func (adder *defaultDepositAdder) AddDeposit(ctx context.Context, tokenAddress, partnerAddress common.Address, delta *util.Amount) (*Channel, error) {
+	if delta.Sign() <= 0 {
+		return nil, fmt.Errorf("deposit amount %s must be greater than zero", delta)
+	}
+
+	channel, _, err := adder.deposit(ctx, tokenAddress, partnerAddress, func(current *Channel) *util.Amount {
+		return current.TotalDeposit.Add(delta)
+	})
+
+	return channel, err
+}
+
+// DepositUpTo will deposit into a payment channel given a token address and a
+// partner address until its balance reaches the given balance. The deposit is
+// computed from the channel as read right before depositing and sent as a new
+// total deposit, so a deposit made by someone else in the meantime counts toward
+// the balance instead of being added on top of it. It returns the channel and
+// whether a deposit was made, which is not the case if the channel's balance
+// already reached the given balance.
+func (adder *defaultDepositAdder) DepositUpTo(ctx context.Context, tokenAddress, partnerAddress common.Address, balance *util.Amount) (*Channel, bool, error) {
+	return adder.deposit(ctx, tokenAddress, partnerAddress, func(current *Channel) *util.Amount {
+		if current.Balance.Cmp(balance) >= 0 {
+			return nil
+		}
+
+		return current.TotalDeposit.Add(balance.Sub(current.Balance))
+	})
+}
+
+// deposit reads the channel and sets its total deposit to the one returned by
+// totalDeposit, or returns the channel unchanged if it returns nil. The channel is
+// read again after a conflict caused by another deposit.
+func (adder *defaultDepositAdder) deposit(ctx context.Context, tokenAddress, partnerAddress common.Address, totalDeposit func(*Channel) *util.Amount) (*Channel, bool, error) {
+	var (
+		err      error
+		current  *Channel
+		channel  *Channel
+		total    *util.Amount
+		conflict error
+		previous *util.Amount
+		lock     = adder.lock(tokenAddress, partnerAddress)
+	)
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	for attempt := 1; ; attempt++ {
+		if current, err = adder.getter.Get(ctx, tokenAddress, partnerAddress); err != nil {
+			return nil, false, err
+		}
+
+		// a conflict that was not caused by a concurrent deposit would only repeat
+		if previous != nil && current.TotalDeposit.Cmp(previous) == 0 {
+			return nil, false, conflict
+		}
+
+		if total = totalDeposit(current); total == nil {
+			return current, false, nil
+		}
+
+		if channel, err = adder.depositor.IncreaseDeposit(ctx, tokenAddress, partnerAddress, total); err == nil {
+			return channel, true, nil
+		}
+
+		if !util.IsConflict(err) || attempt >= DefaultAddDepositAttempts {
+			return nil, false, err
+		}
+
+		conflict = err
+		previous = current.TotalDeposit
+	}
+}
+
+func (adder *defaultDepositAdder) lock(tokenAddress, partnerAddress common.Address) *sync.Mutex {
+	adder.mutex.Lock()
+	defer adder.mutex.Unlock()
+
+	key := watchKey{token: tokenAddress, partner: partnerAddress}
+
+	if _, ok := adder.locks[key]; !ok {
+		adder.locks[key] = &sync.Mutex{}
+	}
+
+	return adder.locks[key]
+}
@@ -0,0 +1,244 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleDepositAdder() {
+	var (
+		channelClient *Client
+		config        = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		tokenAddress   = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359") // DAI Stablecoin
+		partnerAddress = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
+		channel        *Channel
+		err            error
+	)
+
+	channelClient = NewClient(config, http.DefaultClient)
+
+	if channel, err = channelClient.AddDeposit(context.Background(), tokenAddress, partnerAddress, util.NewAmount(100)); err != nil {
+		panic(fmt.Sprintf("unable to add to channel deposit: %s", err.Error()))
+	}
+
+	fmt.Printf("new total deposit: %s\n", channel.TotalDeposit)
+}
+
+func TestDepositAdder(t *testing.T) {
+	var (
+		localhostIP = "[::1]"
+		config      = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		channelURL = "http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9"
+	)
+
+	if os.Getenv("USE_IPV4") != "" {
+		localhostIP = "127.0.0.1"
+	}
+
+	channelJSON := func(totalDeposit int64) string {
+		return fmt.Sprintf(`{"token_network_identifier":"0xE5637F0103794C7e05469A9964E4563089a5E6f2","channel_identifier":20,"partner_address":"0x61C808D82A3Ac53231750daDc13c777b59310bD9","token_address":"0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8","balance":%d,"total_deposit":%d,"state":"opened","settle_timeout":500,"reveal_timeout":30}`, totalDeposit, totalDeposit)
+	}
+
+	// registerNode serves the channel with the given total deposit and applies
+	// the total deposits sent to it. Deposits listed in concurrent are applied by
+	// another party right before the node handles the corresponding request, and
+	// conflicts lists the requests rejected regardless of their total deposit.
+	registerNode := func(totalDeposit int64, concurrent map[int]int64, conflicts map[int]bool, requested *[]int64) {
+		httpmock.RegisterResponder("GET", channelURL, func(request *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, channelJSON(totalDeposit)), nil
+		})
+
+		httpmock.RegisterResponder("PATCH", channelURL, func(request *http.Request) (*http.Response, error) {
+			var (
+				depositRequest = &increaseDepositRequest{}
+				attempt        = len(*requested)
+				response       *http.Response
+			)
+
+			if err := json.NewDecoder(request.Body).Decode(depositRequest); err != nil {
+				return nil, err
+			}
+
+			*requested = append(*requested, depositRequest.TotalDeposit.Big().Int64())
+
+			if deposit, ok := concurrent[attempt]; ok {
+				totalDeposit += deposit
+			}
+
+			if conflicts[attempt] || depositRequest.TotalDeposit.Big().Int64() <= totalDeposit {
+				response = httpmock.NewStringResponse(http.StatusConflict, `{"errors":"Total deposit did not increase"}`)
+			} else {
+				totalDeposit = depositRequest.TotalDeposit.Big().Int64()
+				response = httpmock.NewStringResponse(http.StatusOK, channelJSON(totalDeposit))
+			}
+
+			response.Request = request
+
+			return response, nil
+		})
+	}
+
+	type testcase struct {
+		name                 string
+		prepHTTPMock         func(requested *[]int64)
+		delta                *util.Amount
+		upTo                 *util.Amount
+		expectedTotalDeposit *util.Amount
+		expectedRequests     []int64
+		expectedError        error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "successfully added deposit",
+			prepHTTPMock: func(requested *[]int64) {
+				registerNode(1000, nil, nil, requested)
+			},
+			delta:                util.NewAmount(100),
+			expectedTotalDeposit: util.NewAmount(1100),
+			expectedRequests:     []int64{1100},
+		},
+		testcase{
+			name: "deposit raced by another deposit",
+			prepHTTPMock: func(requested *[]int64) {
+				registerNode(1000, map[int]int64{0: 500}, nil, requested)
+			},
+			delta:                util.NewAmount(100),
+			expectedTotalDeposit: util.NewAmount(1600),
+			expectedRequests:     []int64{1100, 1600},
+		},
+		testcase{
+			name: "deposit raced on every attempt",
+			prepHTTPMock: func(requested *[]int64) {
+				registerNode(1000, map[int]int64{0: 500, 1: 500, 2: 500}, nil, requested)
+			},
+			delta:            util.NewAmount(100),
+			expectedRequests: []int64{1100, 1600, 2100},
+			expectedError:    errors.New("PATCH " + channelURL + ": 409 Conflict: Total deposit did not increase"),
+		},
+		testcase{
+			name: "conflict not caused by another deposit",
+			prepHTTPMock: func(requested *[]int64) {
+				registerNode(1000, nil, map[int]bool{0: true}, requested)
+			},
+			delta:            util.NewAmount(100),
+			expectedRequests: []int64{1100},
+			expectedError:    errors.New("PATCH " + channelURL + ": 409 Conflict: Total deposit did not increase"),
+		},
+		testcase{
+			name: "deposit up to a balance",
+			prepHTTPMock: func(requested *[]int64) {
+				registerNode(1000, nil, nil, requested)
+			},
+			upTo:                 util.NewAmount(1500),
+			expectedTotalDeposit: util.NewAmount(1500),
+			expectedRequests:     []int64{1500},
+		},
+		testcase{
+			name: "deposit up to a balance raced by a smaller deposit",
+			prepHTTPMock: func(requested *[]int64) {
+				registerNode(1000, map[int]int64{0: 300}, nil, requested)
+			},
+			upTo:                 util.NewAmount(1500),
+			expectedTotalDeposit: util.NewAmount(1500),
+			expectedRequests:     []int64{1500},
+		},
+		testcase{
+			name: "deposit up to a balance already reached",
+			prepHTTPMock: func(requested *[]int64) {
+				registerNode(1000, nil, nil, requested)
+			},
+			upTo:                 util.NewAmount(800),
+			expectedTotalDeposit: util.NewAmount(1000),
+			expectedRequests:     []int64{},
+		},
+		testcase{
+			name: "deposit amount not positive",
+			prepHTTPMock: func(requested *[]int64) {
+				registerNode(1000, nil, nil, requested)
+			},
+			delta:            util.NewAmount(0),
+			expectedRequests: []int64{},
+			expectedError:    errors.New("deposit amount 0 must be greater than zero"),
+		},
+		testcase{
+			name: "unexpected 500 response",
+			prepHTTPMock: func(requested *[]int64) {
+				httpmock.RegisterResponder(
+					"GET",
+					channelURL,
+					httpmock.NewStringResponder(
+						http.StatusInternalServerError,
+						``,
+					),
+				)
+			},
+			delta:            util.NewAmount(100),
+			expectedRequests: []int64{},
+			expectedError:    &util.APIError{StatusCode: http.StatusInternalServerError, Method: "GET", URL: channelURL},
+		},
+		testcase{
+			name: "unable to make http request",
+			prepHTTPMock: func(requested *[]int64) {
+				httpmock.Deactivate()
+			},
+			delta:            util.NewAmount(100),
+			expectedRequests: []int64{},
+			expectedError:    fmt.Errorf("Get %s: dial tcp %s:5001: connect: connection refused", channelURL, localhostIP),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				err            error
+				channel        *Channel
+				requested      = make([]int64, 0)
+				tokenAddress   = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+				partnerAddress = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
+
+				adder = NewDepositAdder(config, http.DefaultClient)
+				ctx   = context.Background()
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			tc.prepHTTPMock(&requested)
+
+			if tc.upTo != nil {
+				channel, _, err = adder.DepositUpTo(ctx, tokenAddress, partnerAddress, tc.upTo)
+			} else {
+				channel, err = adder.AddDeposit(ctx, tokenAddress, partnerAddress, tc.delta)
+			}
+
+			assert.Equal(t, tc.expectedRequests, requested)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTotalDeposit, channel.TotalDeposit)
+		})
+	}
+}
@@ -0,0 +1,486 @@
+package channels
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// ppm is the denominator of proportional fees, which are given in parts per
+	// million of the mediated amount.
+	ppm = 1000000
+	// imbalancePenaltyPoints is the number of points of the penalty curve created
+	// by ImbalancePenalty.
+	imbalancePenaltyPoints = 21
+)
+
+var (
+	// imbalancePenaltySlope is the steepest slope of the penalty curve created by
+	// ImbalancePenalty.
+	imbalancePenaltySlope = big.NewRat(1, 10)
+	// imbalancePenaltyMaxExponent is the largest exponent of the penalty curve
+	// created by ImbalancePenalty.
+	imbalancePenaltyMaxExponent = big.NewRat(10, 1)
+)
+
+// PenaltyPoint is a point of an imbalance penalty curve: the penalty charged
+// while our balance in the channel is Balance.
+type PenaltyPoint struct {
+	Balance *util.Amount
+	Penalty *util.Amount
+}
+
+// FeeSchedule describes the fees a mediating node charges for a channel, modelled
+// after the fee schedules of Raiden nodes. A Raiden node is configured with its
+// fees when it is started and does not expose or accept fee schedules through its
+// REST API, so FeeSchedule is only used to predict the fees locally.
+type FeeSchedule struct {
+	// Flat is the fixed fee per mediated payment. Like Raiden, half of it is
+	// charged on the incoming and half on the outgoing channel.
+	Flat *util.Amount
+	// Proportional is the fee in parts per million of the mediated amount.
+	Proportional int64
+	// ImbalancePenalty is a curve of penalties by our balance in the channel. The
+	// change of the penalty caused by a payment is added to the fee, so payments
+	// that balance the channel are cheaper or even rewarded. It is interpolated
+	// linearly between points and must cover every balance a payment reaches.
+	ImbalancePenalty []*PenaltyPoint
+	// CapFees prevents the total fee of a mediation from becoming negative.
+	CapFees bool
+}
+
+// Direction is the direction a mediated payment passes a channel from the point of
+// view of our node.
+type Direction int
+
+const (
+	// Incoming is the channel a mediated payment is received on, which increases
+	// our balance.
+	Incoming Direction = iota
+	// Outgoing is the channel a mediated payment is forwarded on, which decreases
+	// our balance.
+	Outgoing
+)
+
+// Validate checks that the fees are not negative and that the imbalance penalty
+// points have distinct balances.
+func (schedule *FeeSchedule) Validate() error {
+	if schedule.Flat.Sign() < 0 {
+		return fmt.Errorf("flat fee %s must not be negative", schedule.Flat)
+	}
+
+	if schedule.Proportional < 0 {
+		return fmt.Errorf("proportional fee %d must not be negative", schedule.Proportional)
+	}
+
+	points := schedule.sortedPenalty()
+
+	for i := 1; i < len(points); i++ {
+		if points[i].Balance.Cmp(points[i-1].Balance) == 0 {
+			return fmt.Errorf("imbalance penalty has more than one point at balance %s", points[i].Balance)
+		}
+	}
+
+	return nil
+}
+
+// Fee predicts the fee charged for mediating amount over the channel in the given
+// direction. The fee may be negative if the imbalance penalty rewards the payment.
+func (schedule *FeeSchedule) Fee(channel *Channel, amount *util.Amount, direction Direction) (*util.Amount, error) {
+	fee, err := schedule.fee(channel, amount, direction)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return util.NewAmountFromBig(round(fee)), nil
+}
+
+func (schedule *FeeSchedule) fee(channel *Channel, amount *util.Amount, direction Direction) (*big.Rat, error) {
+	var (
+		err          error
+		balance      = channel.Balance.Big()
+		newBalance   = new(big.Int)
+		penaltyDelta *big.Rat
+		fee          = new(big.Rat).SetFrac(schedule.Flat.Big(), big.NewInt(2))
+	)
+
+	if amount.Sign() <= 0 {
+		return nil, errors.New("mediated amount must be greater than zero")
+	}
+
+	switch direction {
+	case Incoming:
+		newBalance.Add(balance, amount.Big())
+	case Outgoing:
+		if amount.Cmp(channel.Balance) > 0 {
+			return nil, fmt.Errorf("mediated amount %s exceeds the balance %s of channel %d", amount, channel.Balance, channel.ChannelIdentifier)
+		}
+
+		newBalance.Sub(balance, amount.Big())
+	default:
+		return nil, fmt.Errorf("invalid direction %d", direction)
+	}
+
+	fee.Add(fee, new(big.Rat).SetFrac(new(big.Int).Mul(amount.Big(), big.NewInt(schedule.Proportional)), big.NewInt(ppm)))
+
+	if penaltyDelta, err = schedule.penaltyDelta(balance, newBalance); err != nil {
+		return nil, fmt.Errorf("unable to calculate the fee of channel %d: %s", channel.ChannelIdentifier, err.Error())
+	}
+
+	return fee.Add(fee, penaltyDelta), nil
+}
+
+func (schedule *FeeSchedule) penaltyDelta(balance, newBalance *big.Int) (*big.Rat, error) {
+	var (
+		err           error
+		before, after *big.Rat
+		points        = schedule.sortedPenalty()
+	)
+
+	if len(points) == 0 {
+		return new(big.Rat), nil
+	}
+
+	if before, err = interpolate(points, balance); err != nil {
+		return nil, err
+	}
+
+	if after, err = interpolate(points, newBalance); err != nil {
+		return nil, err
+	}
+
+	return after.Sub(after, before), nil
+}
+
+func (schedule *FeeSchedule) sortedPenalty() []*PenaltyPoint {
+	points := make([]*PenaltyPoint, len(schedule.ImbalancePenalty))
+	copy(points, schedule.ImbalancePenalty)
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Balance.Cmp(points[j].Balance) < 0
+	})
+
+	return points
+}
+
+// interpolate returns the penalty at balance x of the sorted penalty points.
+func interpolate(points []*PenaltyPoint, x *big.Int) (*big.Rat, error) {
+	var (
+		first = points[0]
+		last  = points[len(points)-1]
+	)
+
+	if x.Cmp(first.Balance.Big()) < 0 || x.Cmp(last.Balance.Big()) > 0 {
+		return nil, fmt.Errorf("balance %s is outside of the imbalance penalty range from %s to %s", x, first.Balance, last.Balance)
+	}
+
+	for i := 1; i < len(points); i++ {
+		var (
+			x0 = points[i-1].Balance.Big()
+			x1 = points[i].Balance.Big()
+			y0 = points[i-1].Penalty.Big()
+			y1 = points[i].Penalty.Big()
+		)
+
+		if x.Cmp(x1) > 0 {
+			continue
+		}
+
+		// y0 + (x - x0) * (y1 - y0) / (x1 - x0)
+		slope := new(big.Rat).SetFrac(new(big.Int).Sub(y1, y0), new(big.Int).Sub(x1, x0))
+		offset := new(big.Rat).SetInt(new(big.Int).Sub(x, x0))
+
+		return new(big.Rat).Add(new(big.Rat).SetInt(y0), offset.Mul(offset, slope)), nil
+	}
+
+	return new(big.Rat).SetInt(first.Penalty.Big()), nil
+}
+
+// round rounds a rational to the nearest integer, halves away from zero.
+func round(value *big.Rat) *big.Int {
+	var (
+		doubled  = new(big.Int).Mul(value.Num(), big.NewInt(2))
+		quotient = new(big.Int).Quo(doubled, value.Denom())
+	)
+
+	// quotient is twice the value truncated towards zero, so adding its own
+	// remainder of the division by two rounds halves away from zero
+	return quotient.Quo(quotient.Add(quotient, new(big.Int).Rem(quotient, big.NewInt(2))), big.NewInt(2))
+}
+
+// ImbalancePenalty creates a penalty curve for a channel with the given capacity,
+// the sum of both partners' balances, like Raiden nodes do for their proportional
+// imbalance fee setting. The penalty is zero for a balanced channel and rises
+// towards proportionalImbalance parts per million of the capacity as our balance
+// approaches either end.
+func ImbalancePenalty(capacity *util.Amount, proportionalImbalance int64) []*PenaltyPoint {
+	var (
+		points     = make([]*PenaltyPoint, 0, imbalancePenaltyPoints)
+		count      = int64(imbalancePenaltyPoints)
+		center     = new(big.Rat).SetFrac(capacity.Big(), big.NewInt(2))
+		maxPenalty = new(big.Rat).SetFrac(new(big.Int).Mul(capacity.Big(), big.NewInt(proportionalImbalance)), big.NewInt(ppm))
+	)
+
+	if proportionalImbalance <= 0 || capacity.Sign() <= 0 {
+		return points
+	}
+
+	if capacity.Big().IsInt64() && capacity.Big().Int64()+1 < count {
+		count = capacity.Big().Int64() + 1
+	}
+
+	// penalty(x) = maxPenalty * (|x - center| / center)^exponent with the
+	// steepest slope at the ends
+	var (
+		exponent  = new(big.Rat).Quo(new(big.Rat).Mul(imbalancePenaltySlope, center), maxPenalty)
+		precision = uint(maxPenalty.Num().BitLen()) + 64
+	)
+
+	if exponent.Cmp(imbalancePenaltyMaxExponent) > 0 {
+		exponent = imbalancePenaltyMaxExponent
+	}
+
+	for i := int64(0); i < count; i++ {
+		// ceil(capacity * i / (count - 1))
+		numerator := new(big.Int).Mul(capacity.Big(), big.NewInt(i))
+		x, remainder := new(big.Int).QuoRem(numerator, big.NewInt(count-1), new(big.Int))
+
+		if remainder.Sign() > 0 {
+			x.Add(x, big.NewInt(1))
+		}
+
+		distance := new(big.Rat).Sub(new(big.Rat).SetInt(x), center)
+		penalty := new(big.Int)
+
+		if distance.Sign() != 0 {
+			ratio := distance.Quo(distance.Abs(distance), center)
+			penalty = round(new(big.Rat).Mul(maxPenalty, pow(ratio, exponent, precision)))
+		}
+
+		points = append(points, &PenaltyPoint{
+			Balance: util.NewAmountFromBig(x),
+			Penalty: util.NewAmountFromBig(penalty),
+		})
+	}
+
+	return points
+}
+
+// pow returns base^exponent for a base in (0, 1] and a positive exponent as
+// exp(exponent * ln(base)), accurate to precision bits after the binary point.
+func pow(base, exponent *big.Rat, precision uint) *big.Rat {
+	return exp(truncate(new(big.Rat).Mul(exponent, ln(base, precision)), precision), precision)
+}
+
+// ln returns the natural logarithm of a positive rational. The value is scaled by
+// a power of two into [1/2, 1], where the series of ln converges quickly.
+func ln(value *big.Rat, precision uint) *big.Rat {
+	var (
+		mantissa = new(big.Rat).Set(value)
+		shift    = int64(0)
+		half     = big.NewRat(1, 2)
+		one      = big.NewRat(1, 1)
+	)
+
+	for mantissa.Cmp(half) < 0 {
+		mantissa.Mul(mantissa, big.NewRat(2, 1))
+		shift--
+	}
+
+	for mantissa.Cmp(one) > 0 {
+		mantissa.Quo(mantissa, big.NewRat(2, 1))
+		shift++
+	}
+
+	result := lnSeries(mantissa, precision)
+
+	return result.Add(result, new(big.Rat).Mul(big.NewRat(shift, 1), lnSeries(big.NewRat(2, 1), precision)))
+}
+
+// lnSeries returns ln(value) = 2 * atanh((value - 1) / (value + 1)) summed until
+// the terms drop below the precision.
+func lnSeries(value *big.Rat, precision uint) *big.Rat {
+	var (
+		one      = big.NewRat(1, 1)
+		z        = new(big.Rat).Quo(new(big.Rat).Sub(value, one), new(big.Rat).Add(value, one))
+		zSquared = new(big.Rat).Mul(z, z)
+		term     = new(big.Rat).Set(z)
+		sum      = new(big.Rat)
+		epsilon  = new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Lsh(big.NewInt(1), precision))
+	)
+
+	for k := int64(1); ; k += 2 {
+		addend := new(big.Rat).Quo(term, big.NewRat(k, 1))
+
+		if new(big.Rat).Abs(addend).Cmp(epsilon) < 0 {
+			break
+		}
+
+		sum = truncate(sum.Add(sum, addend), precision)
+		term = truncate(term.Mul(term, zSquared), precision)
+	}
+
+	return sum.Mul(sum, big.NewRat(2, 1))
+}
+
+// exp returns e^value for a value that is not positive. The value is split into a
+// multiple of ln(2) and a remainder in [0, ln(2)), whose series converges quickly.
+func exp(value *big.Rat, precision uint) *big.Rat {
+	var (
+		ln2       = lnSeries(big.NewRat(2, 1), precision)
+		quotient  = new(big.Rat).Quo(value, ln2)
+		shift     = new(big.Int).Div(quotient.Num(), quotient.Denom())
+		remainder = new(big.Rat).Sub(value, new(big.Rat).Mul(new(big.Rat).SetInt(shift), ln2))
+		term      = big.NewRat(1, 1)
+		sum       = big.NewRat(1, 1)
+		epsilon   = new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Lsh(big.NewInt(1), precision))
+	)
+
+	for n := int64(1); term.Cmp(epsilon) >= 0; n++ {
+		term = truncate(term.Mul(term, remainder).Quo(term, big.NewRat(n, 1)), precision)
+		sum.Add(sum, term)
+	}
+
+	// shift is not positive for a value that is not positive
+	return sum.Quo(sum, new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), uint(-shift.Int64()))))
+}
+
+// truncate rounds a rational towards negative infinity to precision bits after
+// the binary point, which keeps the numbers of a series from growing.
+func truncate(value *big.Rat, precision uint) *big.Rat {
+	var (
+		scale  = new(big.Int).Lsh(big.NewInt(1), precision)
+		scaled = new(big.Int).Mul(value.Num(), scale)
+	)
+
+	return value.SetFrac(scaled.Div(scaled, value.Denom()), scale)
+}
+
+// feeScheduleKey identifies the channel with a partner in a token network that a
+// fee schedule applies to.
+type feeScheduleKey struct {
+	token   common.Address
+	partner common.Address
+}
+
+// FeeSchedules keeps the fee schedules of a mediating node by token and channel
+// and predicts the fees it charges. Since Raiden nodes take their fees from
+// start-up flags rather than the REST API, schedules set here do not change the
+// node's fees and must mirror its configuration. FeeSchedules is safe for
+// concurrent use.
+type FeeSchedules struct {
+	mutex    sync.RWMutex
+	tokens   map[common.Address]*FeeSchedule
+	channels map[feeScheduleKey]*FeeSchedule
+}
+
+// NewFeeSchedules creates fee schedules in which every channel is free of fees.
+func NewFeeSchedules() *FeeSchedules {
+	return &FeeSchedules{
+		tokens:   make(map[common.Address]*FeeSchedule),
+		channels: make(map[feeScheduleKey]*FeeSchedule),
+	}
+}
+
+// SetTokenSchedule sets the fee schedule of all channels of a token network that
+// have no schedule of their own.
+func (schedules *FeeSchedules) SetTokenSchedule(tokenAddress common.Address, schedule *FeeSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+
+	schedules.mutex.Lock()
+	defer schedules.mutex.Unlock()
+
+	schedules.tokens[tokenAddress] = schedule
+
+	return nil
+}
+
+// SetChannelSchedule sets the fee schedule of the channel with the partner in a
+// token network, overriding the schedule of the token network.
+func (schedules *FeeSchedules) SetChannelSchedule(tokenAddress, partnerAddress common.Address, schedule *FeeSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+
+	schedules.mutex.Lock()
+	defer schedules.mutex.Unlock()
+
+	schedules.channels[feeScheduleKey{token: tokenAddress, partner: partnerAddress}] = schedule
+
+	return nil
+}
+
+// RemoveChannelSchedule removes the schedule of a channel so that the schedule of
+// its token network applies again.
+func (schedules *FeeSchedules) RemoveChannelSchedule(tokenAddress, partnerAddress common.Address) {
+	schedules.mutex.Lock()
+	defer schedules.mutex.Unlock()
+
+	delete(schedules.channels, feeScheduleKey{token: tokenAddress, partner: partnerAddress})
+}
+
+// Schedule returns the fee schedule of the channel with the partner in a token
+// network: its own schedule, the schedule of the token network or an empty
+// schedule without fees.
+func (schedules *FeeSchedules) Schedule(tokenAddress, partnerAddress common.Address) *FeeSchedule {
+	schedules.mutex.RLock()
+	defer schedules.mutex.RUnlock()
+
+	if schedule, ok := schedules.channels[feeScheduleKey{token: tokenAddress, partner: partnerAddress}]; ok {
+		return schedule
+	}
+
+	if schedule, ok := schedules.tokens[tokenAddress]; ok {
+		return schedule
+	}
+
+	return &FeeSchedule{}
+}
+
+// Fee predicts the fee charged on the channel for mediating amount in the given
+// direction according to the channel's schedule.
+func (schedules *FeeSchedules) Fee(channel *Channel, amount *util.Amount, direction Direction) (*util.Amount, error) {
+	return schedules.Schedule(channel.TokenAddress, channel.PartnerAddress).Fee(channel, amount, direction)
+}
+
+// MediationFee predicts the total fee our node charges for receiving amount on
+// the incoming channel and forwarding it on the outgoing channel, which is the
+// revenue of the mediation.
+func (schedules *FeeSchedules) MediationFee(incoming, outgoing *Channel, amount *util.Amount) (*util.Amount, error) {
+	var (
+		err              error
+		incomingSchedule = schedules.Schedule(incoming.TokenAddress, incoming.PartnerAddress)
+		outgoingSchedule = schedules.Schedule(outgoing.TokenAddress, outgoing.PartnerAddress)
+		incomingFee      *big.Rat
+		outgoingFee      *big.Rat
+	)
+
+	if incoming.TokenAddress != outgoing.TokenAddress {
+		return nil, errors.New("incoming and outgoing channel must belong to the same token network")
+	}
+
+	if incomingFee, err = incomingSchedule.fee(incoming, amount, Incoming); err != nil {
+		return nil, err
+	}
+
+	if outgoingFee, err = outgoingSchedule.fee(outgoing, amount, Outgoing); err != nil {
+		return nil, err
+	}
+
+	fee := round(incomingFee.Add(incomingFee, outgoingFee))
+
+	if (incomingSchedule.CapFees || outgoingSchedule.CapFees) && fee.Sign() < 0 {
+		fee.SetInt64(0)
+	}
+
+	return util.NewAmountFromBig(fee), nil
+}
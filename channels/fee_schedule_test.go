@@ -0,0 +1,195 @@
+package channels
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleFeeSchedules() {
+	var (
+		schedules    = NewClient(&config.Config{Host: "http://localhost:5001", APIVersion: "v1"}, http.DefaultClient).FeeSchedules
+		tokenAddress = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359") // DAI Stablecoin
+		incoming     = &Channel{ChannelIdentifier: 1, TokenAddress: tokenAddress, PartnerAddress: common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9"), Balance: util.NewAmount(500)}
+		outgoing     = &Channel{ChannelIdentifier: 2, TokenAddress: tokenAddress, PartnerAddress: common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226"), Balance: util.NewAmount(500)}
+	)
+
+	// mirror the fee flags the Raiden node was started with
+	if err := schedules.SetTokenSchedule(tokenAddress, &FeeSchedule{Flat: util.NewAmount(10), Proportional: 1000}); err != nil {
+		panic(fmt.Sprintf("unable to set fee schedule: %s", err.Error()))
+	}
+
+	fee, err := schedules.MediationFee(incoming, outgoing, util.NewAmount(100))
+
+	if err != nil {
+		panic(fmt.Sprintf("unable to calculate fee: %s", err.Error()))
+	}
+
+	fmt.Println(fee)
+	// Output: 10
+}
+
+func TestFeeSchedules(t *testing.T) {
+	var (
+		tokenAddress = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+		partnerA     = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
+		partnerB     = common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226")
+		penalty      = []*PenaltyPoint{
+			&PenaltyPoint{Balance: util.NewAmount(0), Penalty: util.NewAmount(100)},
+			&PenaltyPoint{Balance: util.NewAmount(1000), Penalty: util.NewAmount(100)},
+			&PenaltyPoint{Balance: util.NewAmount(500), Penalty: util.NewAmount(0)},
+		}
+		schedule = &FeeSchedule{Flat: util.NewAmount(10), Proportional: 1000, ImbalancePenalty: penalty}
+	)
+
+	channelWithBalance := func(id int64, partner common.Address, balance int64) *Channel {
+		return &Channel{ChannelIdentifier: id, TokenAddress: tokenAddress, PartnerAddress: partner, Balance: util.NewAmount(balance)}
+	}
+
+	type testcase struct {
+		name          string
+		schedule      *FeeSchedule
+		incoming      *Channel
+		outgoing      *Channel
+		amount        *util.Amount
+		expectedFee   *util.Amount
+		expectedError error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:        "no fees",
+			schedule:    &FeeSchedule{},
+			incoming:    channelWithBalance(1, partnerA, 500),
+			outgoing:    channelWithBalance(2, partnerB, 500),
+			amount:      util.NewAmount(100),
+			expectedFee: util.NewAmount(0),
+		},
+		testcase{
+			name:        "payment imbalances both channels",
+			schedule:    schedule,
+			incoming:    channelWithBalance(1, partnerA, 500),
+			outgoing:    channelWithBalance(2, partnerB, 500),
+			amount:      util.NewAmount(100),
+			expectedFee: util.NewAmount(50),
+		},
+		testcase{
+			name:        "payment balances both channels",
+			schedule:    schedule,
+			incoming:    channelWithBalance(1, partnerA, 300),
+			outgoing:    channelWithBalance(2, partnerB, 700),
+			amount:      util.NewAmount(100),
+			expectedFee: util.NewAmount(-30),
+		},
+		testcase{
+			name:        "capped fees",
+			schedule:    &FeeSchedule{Flat: util.NewAmount(10), Proportional: 1000, ImbalancePenalty: penalty, CapFees: true},
+			incoming:    channelWithBalance(1, partnerA, 300),
+			outgoing:    channelWithBalance(2, partnerB, 700),
+			amount:      util.NewAmount(100),
+			expectedFee: util.NewAmount(0),
+		},
+		testcase{
+			name:          "amount exceeds outgoing balance",
+			schedule:      schedule,
+			incoming:      channelWithBalance(1, partnerA, 300),
+			outgoing:      channelWithBalance(2, partnerB, 100),
+			amount:        util.NewAmount(200),
+			expectedError: errors.New("mediated amount 200 exceeds the balance 100 of channel 2"),
+		},
+		testcase{
+			name:          "balance outside of the penalty curve",
+			schedule:      schedule,
+			incoming:      channelWithBalance(1, partnerA, 900),
+			outgoing:      channelWithBalance(2, partnerB, 700),
+			amount:        util.NewAmount(200),
+			expectedError: errors.New("unable to calculate the fee of channel 1: balance 1100 is outside of the imbalance penalty range from 0 to 1000"),
+		},
+		testcase{
+			name:          "zero amount",
+			schedule:      schedule,
+			incoming:      channelWithBalance(1, partnerA, 500),
+			outgoing:      channelWithBalance(2, partnerB, 500),
+			amount:        util.NewAmount(0),
+			expectedError: errors.New("mediated amount must be greater than zero"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedules := NewFeeSchedules()
+
+			require.NoError(t, schedules.SetTokenSchedule(tokenAddress, tc.schedule))
+
+			fee, err := schedules.MediationFee(tc.incoming, tc.outgoing, tc.amount)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFee, fee)
+		})
+	}
+
+	schedules := NewFeeSchedules()
+	require.NoError(t, schedules.SetTokenSchedule(tokenAddress, schedule))
+
+	fee, err := schedules.Fee(channelWithBalance(1, partnerA, 500), util.NewAmount(100), Incoming)
+	require.NoError(t, err)
+	assert.Equal(t, util.NewAmount(25), fee)
+
+	// a channel schedule overrides the schedule of its token network
+	flat := &FeeSchedule{Flat: util.NewAmount(4)}
+	require.NoError(t, schedules.SetChannelSchedule(tokenAddress, partnerA, flat))
+	assert.Equal(t, flat, schedules.Schedule(tokenAddress, partnerA))
+	assert.Equal(t, schedule, schedules.Schedule(tokenAddress, partnerB))
+
+	fee, err = schedules.Fee(channelWithBalance(1, partnerA, 500), util.NewAmount(100), Outgoing)
+	require.NoError(t, err)
+	assert.Equal(t, util.NewAmount(2), fee)
+
+	schedules.RemoveChannelSchedule(tokenAddress, partnerA)
+	assert.Equal(t, schedule, schedules.Schedule(tokenAddress, partnerA))
+	assert.Equal(t, &FeeSchedule{}, schedules.Schedule(partnerB, partnerA))
+
+	_, err = schedules.MediationFee(channelWithBalance(1, partnerA, 500), &Channel{TokenAddress: partnerB, Balance: util.NewAmount(500)}, util.NewAmount(100))
+	assert.EqualError(t, err, "incoming and outgoing channel must belong to the same token network")
+
+	assert.EqualError(t, schedules.SetTokenSchedule(tokenAddress, &FeeSchedule{Flat: util.NewAmount(-1)}), "flat fee -1 must not be negative")
+	assert.EqualError(t, schedules.SetChannelSchedule(tokenAddress, partnerA, &FeeSchedule{Proportional: -1}), "proportional fee -1 must not be negative")
+	assert.EqualError(t, schedules.SetTokenSchedule(tokenAddress, &FeeSchedule{ImbalancePenalty: append(penalty, &PenaltyPoint{Balance: util.NewAmount(500), Penalty: util.NewAmount(1)})}), "imbalance penalty has more than one point at balance 500")
+}
+
+func TestImbalancePenalty(t *testing.T) {
+	points := ImbalancePenalty(util.NewAmount(1000), 100000)
+
+	require.Len(t, points, 21)
+	assert.Equal(t, &PenaltyPoint{Balance: util.NewAmount(0), Penalty: util.NewAmount(100)}, points[0])
+	assert.Equal(t, &PenaltyPoint{Balance: util.NewAmount(500), Penalty: util.NewAmount(0)}, points[10])
+	assert.Equal(t, &PenaltyPoint{Balance: util.NewAmount(1000), Penalty: util.NewAmount(100)}, points[20])
+
+	// penalties of large channels are exact instead of limited by float precision
+	capacity, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	points = ImbalancePenalty(util.NewAmountFromBig(capacity), 3000)
+
+	require.Len(t, points, 21)
+	assert.Equal(t, util.NewAmount(3000000000000000000), points[0].Penalty)
+	assert.Equal(t, util.NewAmount(1046035320300000000), points[1].Penalty)
+	assert.Equal(t, util.NewAmount(0), points[10].Penalty)
+
+	// small channels get one point per balance
+	assert.Len(t, ImbalancePenalty(util.NewAmount(4), 1000000), 5)
+
+	assert.Empty(t, ImbalancePenalty(util.NewAmount(1000), 0))
+	assert.Empty(t, ImbalancePenalty(util.NewAmount(0), 100000))
+}
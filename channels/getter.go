@@ -0,0 +1,48 @@
+package channels
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Getter represents a generic interface to get a single Payment Channel given a
+// token and partner address.
+type Getter interface {
+	Get(ctx context.Context, tokenAddress, partnerAddress common.Address) (*Channel, error)
+}
+
+var _ Getter = &defaultGetter{}
+
+// NewGetter creates a new default Channel getter given a Raiden node configuration
+// and an http client.
+func NewGetter(config *config.Config, httpClient *http.Client) Getter {
+	return &defaultGetter{
+		baseClient: &util.BaseClient{
+			Config:     config,
+			HTTPClient: httpClient,
+		},
+	}
+}
+
+type defaultGetter struct {
+	baseClient *util.BaseClient
+}
+
+// Get will return the payment channel for the given token address and partner
+// address.
+func (getter *defaultGetter) Get(ctx context.Context, tokenAddress, partnerAddress common.Address) (*Channel, error) {
+	var (
+		err     error
+		channel = &channel{}
+	)
+
+	if err = getter.baseClient.Do(ctx, "GET", []string{"channels", tokenAddress.Hex(), partnerAddress.Hex()}, nil, channel); err != nil {
+		return nil, err
+	}
+
+	return channel.toChannel(), nil
+}
@@ -2,11 +2,7 @@ package channels
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
-	"net/url"
-	"strings"
 
 	"github.com/cpurta/go-raiden-client/config"
 	"github.com/cpurta/go-raiden-client/util"
@@ -14,15 +10,17 @@ import (
 )
 
 type increaseDepositRequest struct {
-	TotalDeposit int64 `json:"total_deposit"`
+	TotalDeposit *util.Amount `json:"total_deposit"`
 }
 
 // IncreaseDepositor represents a generic interface to Increase the Deposit of a Payment Channel given a token and
 // partner address.
 type IncreaseDepositor interface {
-	IncreaseDeposit(ctx context.Context, tokenAddress, partnerAddress common.Address, deposit int64) (*Channel, error)
+	IncreaseDeposit(ctx context.Context, tokenAddress, partnerAddress common.Address, deposit *util.Amount) (*Channel, error)
 }
 
+var _ IncreaseDepositor = &defaultIncreaseDepositor{}
+
 // NewIncreaseDepositor creates a new default Channel depositor increaser given a Raiden node configuration
 // and an http client.
 func NewIncreaseDepositor(config *config.Config, httpClient *http.Client) IncreaseDepositor {
@@ -31,75 +29,39 @@ func NewIncreaseDepositor(config *config.Config, httpClient *http.Client) Increa
 			Config:     config,
 			HTTPClient: httpClient,
 		},
+		getter: NewGetter(config, httpClient),
 	}
 }
 
 type defaultIncreaseDepositor struct {
 	baseClient *util.BaseClient
+	getter     Getter
 }
 
-// Close will increase the deposit a payment channel given a token address and a partner address.
-func (depositor *defaultIncreaseDepositor) IncreaseDeposit(ctx context.Context, tokenAddress, partnerAddress common.Address, deposit int64) (*Channel, error) {
+// IncreaseDeposit will increase the deposit a payment channel given a token address and a partner address.
+// The current channel is fetched first so that depositing into a channel that is not open is rejected
+// before reaching the node.
+func (depositor *defaultIncreaseDepositor) IncreaseDeposit(ctx context.Context, tokenAddress, partnerAddress common.Address, deposit *util.Amount) (*Channel, error) {
 	var (
-		err     error
-		channel = &channel{}
-
-		requestURL             *url.URL
-		request                *http.Request
-		response               *http.Response
-		requestBody            []byte
+		err                    error
+		current                *Channel
+		channel                = &channel{}
 		increaseDepositRequest = &increaseDepositRequest{
 			TotalDeposit: deposit,
 		}
 	)
 
-	if requestURL, err = depositor.getRequestURL(tokenAddress, partnerAddress); err != nil {
-		return nil, err
-	}
-
-	if requestBody, err = json.Marshal(increaseDepositRequest); err != nil {
-		return nil, err
-	}
-
-	if request, err = http.NewRequest("PATCH", requestURL.String(), strings.NewReader(string(requestBody))); err != nil {
-		return nil, err
-	}
-
-	request = request.WithContext(ctx)
-
-	if response, err = depositor.baseClient.HTTPClient.Do(request); err != nil {
+	if current, err = depositor.getter.Get(ctx, tokenAddress, partnerAddress); err != nil {
 		return nil, err
 	}
 
-	defer response.Body.Close()
-
-	if err = json.NewDecoder(response.Body).Decode(&channel); err != nil {
+	if err = validateTransition(current, "deposit into", current.State.CanDeposit()); err != nil {
 		return nil, err
 	}
 
-	return &Channel{
-		TokenNetworkIdentifier: common.HexToAddress(channel.TokenNetworkIdentifier),
-		ChannelIdentifier:      channel.ChannelIdentifier,
-		PartnerAddress:         common.HexToAddress(channel.PartnerAddress),
-		TokenAddress:           common.HexToAddress(channel.TokenAddress),
-		Balance:                channel.Balance,
-		TotalDeposit:           channel.TotalDeposit,
-		State:                  channel.State,
-		SettleTimeout:          channel.SettleTimeout,
-		RevealTimeout:          channel.RevealTimeout,
-	}, nil
-}
-
-func (depositor *defaultIncreaseDepositor) getRequestURL(tokenAddress, partnerAddress common.Address) (*url.URL, error) {
-	var (
-		err        error
-		endpoint   = fmt.Sprintf("%s/api/%s/channels/%s/%s", depositor.baseClient.Config.Host, depositor.baseClient.Config.APIVersion, tokenAddress.Hex(), partnerAddress.Hex())
-		requestURL *url.URL
-	)
-
-	if requestURL, err = url.Parse(endpoint); err != nil {
+	if err = depositor.baseClient.Do(ctx, "PATCH", []string{"channels", tokenAddress.Hex(), partnerAddress.Hex()}, increaseDepositRequest, channel); err != nil {
 		return nil, err
 	}
 
-	return requestURL, nil
+	return channel.toChannel(), nil
 }
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -24,7 +25,7 @@ func ExampleIncreaseDepositor() {
 		}
 		tokenAddress   = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359") // DAI Stablecoin
 		partnerAddress = common.HexToAddress("0x1f7402f55e142820ea3812106d0657103fc1709e")
-		deposit        = int64(1000)
+		deposit        = util.NewAmount(1000)
 		channel        *Channel
 		err            error
 	)
@@ -45,6 +46,8 @@ func TestIncreaseDepositer(t *testing.T) {
 			Host:       "http://localhost:5001",
 			APIVersion: "v1",
 		}
+		currentChannel = `{"token_network_identifier":"0xE5637F0103794C7e05469A9964E4563089a5E6f2","channel_identifier":20,"partner_address":"0x61C808D82A3Ac53231750daDc13c777b59310bD9","token_address":"0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8","balance":25000000,"total_deposit":35000000,"state":"opened","settle_timeout":500,"reveal_timeout":30}`
+		closedChannel  = `{"token_network_identifier":"0xE5637F0103794C7e05469A9964E4563089a5E6f2","channel_identifier":20,"partner_address":"0x61C808D82A3Ac53231750daDc13c777b59310bD9","token_address":"0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8","balance":25000000,"total_deposit":35000000,"state":"closed","settle_timeout":500,"reveal_timeout":30}`
 	)
 
 	if os.Getenv("USE_IPV4") != "" {
@@ -62,6 +65,15 @@ func TestIncreaseDepositer(t *testing.T) {
 		testcase{
 			name: "successfully closed payment channel",
 			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						currentChannel,
+					),
+				)
+
 				httpmock.RegisterResponder(
 					"PATCH",
 					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
@@ -77,8 +89,8 @@ func TestIncreaseDepositer(t *testing.T) {
 				ChannelIdentifier:      int64(20),
 				PartnerAddress:         common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9"),
 				TokenAddress:           common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"),
-				Balance:                int64(25000100),
-				TotalDeposit:           int64(35000100),
+				Balance:                util.NewAmount(25000100),
+				TotalDeposit:           util.NewAmount(35000100),
 				State:                  "opened",
 				SettleTimeout:          int64(500),
 				RevealTimeout:          int64(30),
@@ -87,6 +99,15 @@ func TestIncreaseDepositer(t *testing.T) {
 		testcase{
 			name: "unexpected 500 response",
 			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						currentChannel,
+					),
+				)
+
 				httpmock.RegisterResponder(
 					"PATCH",
 					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
@@ -96,15 +117,30 @@ func TestIncreaseDepositer(t *testing.T) {
 					),
 				)
 			},
-			expectedError:   errors.New("EOF"),
+			expectedError:   &util.APIError{StatusCode: http.StatusInternalServerError, Method: "PATCH", URL: "http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9"},
 			expectedChannel: &Channel{},
 		},
+		testcase{
+			name: "channel is not open",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						closedChannel,
+					),
+				)
+			},
+			expectedError:   errors.New("unable to deposit into channel 20 with partner 0x61C808D82A3Ac53231750daDc13c777b59310bD9: channel is closed"),
+			expectedChannel: nil,
+		},
 		testcase{
 			name: "unable to make http request",
 			prepHTTPMock: func() {
 				httpmock.Deactivate()
 			},
-			expectedError:   fmt.Errorf("Patch http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9: dial tcp %s:5001: connect: connection refused", localhostIP),
+			expectedError:   fmt.Errorf("Get http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9: dial tcp %s:5001: connect: connection refused", localhostIP),
 			expectedChannel: &Channel{},
 		},
 	}
@@ -116,7 +152,7 @@ func TestIncreaseDepositer(t *testing.T) {
 				channel        *Channel
 				tokenAddress   = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
 				partnerAddress = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
-				totalDeposit   = int64(100)
+				totalDeposit   = util.NewAmount(100)
 
 				despositer = NewIncreaseDepositor(config, http.DefaultClient)
 				ctx        = context.Background()
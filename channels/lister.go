@@ -0,0 +1,64 @@
+package channels
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Lister represents a generic interface to list all of the payment channels of a
+// Raiden node, either across all token networks or for a single token.
+type Lister interface {
+	List(ctx context.Context) ([]*Channel, error)
+	ListByToken(ctx context.Context, tokenAddress common.Address) ([]*Channel, error)
+}
+
+var _ Lister = &defaultLister{}
+
+// NewLister creates a new default Channel lister given a Raiden node configuration
+// and an http client.
+func NewLister(config *config.Config, httpClient *http.Client) Lister {
+	return &defaultLister{
+		baseClient: &util.BaseClient{
+			Config:     config,
+			HTTPClient: httpClient,
+		},
+	}
+}
+
+type defaultLister struct {
+	baseClient *util.BaseClient
+}
+
+// List will list all of the payment channels of the Raiden node across all token
+// networks.
+func (lister *defaultLister) List(ctx context.Context) ([]*Channel, error) {
+	return lister.getChannels(ctx, "channels")
+}
+
+// ListByToken will list all of the payment channels of the Raiden node for the
+// given token address.
+func (lister *defaultLister) ListByToken(ctx context.Context, tokenAddress common.Address) ([]*Channel, error) {
+	return lister.getChannels(ctx, "channels", tokenAddress.Hex())
+}
+
+func (lister *defaultLister) getChannels(ctx context.Context, pathSegments ...string) ([]*Channel, error) {
+	var (
+		err      error
+		channels = make([]*channel, 0)
+		results  = make([]*Channel, 0)
+	)
+
+	if err = lister.baseClient.Do(ctx, "GET", pathSegments, nil, &channels); err != nil {
+		return nil, err
+	}
+
+	for _, channel := range channels {
+		results = append(results, channel.toChannel())
+	}
+
+	return results, nil
+}
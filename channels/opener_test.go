@@ -2,13 +2,14 @@ package channels
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"testing"
 
 	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -24,6 +25,7 @@ func ExampleOpener() {
 		}
 		tokenAddress   = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359") // DAI Stablecoin
 		partnerAddress = common.HexToAddress("0x1f7402f55e142820ea3812106d0657103fc1709e")
+		deposit        = util.NewAmount(1000)
 		settleTimeout  = int64(60)
 		channel        *Channel
 		err            error
@@ -31,7 +33,7 @@ func ExampleOpener() {
 
 	channelClient = NewClient(config, http.DefaultClient)
 
-	if channel, err = channelClient.IncreaseDeposit(context.Background(), tokenAddress, partnerAddress, settleTimeout); err != nil {
+	if channel, err = channelClient.Open(context.Background(), tokenAddress, partnerAddress, deposit, settleTimeout); err != nil {
 		panic(fmt.Sprintf("unable to open payment channel: %s", err.Error()))
 	}
 
@@ -77,8 +79,8 @@ func TestOpener(t *testing.T) {
 				ChannelIdentifier:      int64(20),
 				PartnerAddress:         common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9"),
 				TokenAddress:           common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"),
-				Balance:                int64(25000000),
-				TotalDeposit:           int64(35000000),
+				Balance:                util.NewAmount(25000000),
+				TotalDeposit:           util.NewAmount(35000000),
 				State:                  "opened",
 				SettleTimeout:          int64(500),
 				RevealTimeout:          int64(30),
@@ -96,7 +98,7 @@ func TestOpener(t *testing.T) {
 					),
 				)
 			},
-			expectedError:   errors.New("EOF"),
+			expectedError:   &util.APIError{StatusCode: http.StatusInternalServerError, Method: "PUT", URL: "http://localhost:5001/api/v1/channels"},
 			expectedChannel: &Channel{},
 		},
 		testcase{
@@ -116,7 +118,7 @@ func TestOpener(t *testing.T) {
 				channel        *Channel
 				partnerAddress = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
 				tokenAddress   = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
-				totalDeposit   = int64(35000000)
+				totalDeposit   = util.NewAmount(35000000)
 				settleTimeout  = int64(500)
 
 				opener = NewOpener(config, http.DefaultClient)
@@ -140,3 +142,31 @@ func TestOpener(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenerDefaultSettleTimeout(t *testing.T) {
+	var (
+		body   []byte
+		config = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+	)
+
+	httpmock.Activate()
+	defer httpmock.Deactivate()
+
+	httpmock.RegisterResponder("PUT", "http://localhost:5001/api/v1/channels", func(request *http.Request) (*http.Response, error) {
+		body, _ = ioutil.ReadAll(request.Body)
+
+		return httpmock.NewStringResponse(
+			http.StatusCreated,
+			`{"token_network_identifier":"0xE5637F0103794C7e05469A9964E4563089a5E6f2","channel_identifier":20,"partner_address":"0x61C808D82A3Ac53231750daDc13c777b59310bD9","token_address":"0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8","balance":100,"total_deposit":100,"state":"opened","settle_timeout":500,"reveal_timeout":50}`,
+		), nil
+	})
+
+	_, err := NewOpener(config, http.DefaultClient).Open(context.Background(), common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"), common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9"), util.NewAmount(100), 0)
+	require.NoError(t, err)
+
+	// the node rejects a settle timeout of 0, so it must be left out
+	assert.JSONEq(t, `{"partner_address":"0x61C808D82A3Ac53231750daDc13c777b59310bD9","token_address":"0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8","total_deposit":100}`, string(body))
+}
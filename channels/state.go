@@ -0,0 +1,99 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ChannelState is the state of a payment channel as reported by a Raiden node.
+type ChannelState string
+
+const (
+	// StateOpened is the state of a channel that can be used for payments,
+	// deposits and withdraws.
+	StateOpened ChannelState = "opened"
+	// StateClosing is the state of a channel whose close transaction has been sent
+	// but not yet mined.
+	StateClosing ChannelState = "closing"
+	// StateClosed is the state of a channel that was closed and waits for the
+	// settle timeout to expire.
+	StateClosed ChannelState = "closed"
+	// StateSettling is the state of a channel whose settle transaction has been
+	// sent but not yet mined.
+	StateSettling ChannelState = "settling"
+	// StateSettled is the state of a channel that was settled on-chain.
+	StateSettled ChannelState = "settled"
+	// StateUnusable is the state of a channel the node can no longer use, e.g.
+	// because one of its transactions failed.
+	StateUnusable ChannelState = "unusable"
+)
+
+var (
+	channelStates = map[ChannelState]bool{
+		StateOpened:   true,
+		StateClosing:  true,
+		StateClosed:   true,
+		StateSettling: true,
+		StateSettled:  true,
+		StateUnusable: true,
+	}
+)
+
+// ParseChannelState returns the channel state of the given string or an error if
+// it is not a known state.
+func ParseChannelState(value string) (ChannelState, error) {
+	state := ChannelState(value)
+
+	if !channelStates[state] {
+		return "", fmt.Errorf("unknown channel state %q", value)
+	}
+
+	return state, nil
+}
+
+// String returns the state as reported by a Raiden node.
+func (state ChannelState) String() string {
+	return string(state)
+}
+
+// UnmarshalJSON decodes a channel state and rejects unknown states.
+func (state *ChannelState) UnmarshalJSON(data []byte) error {
+	var (
+		err   error
+		value string
+	)
+
+	if err = json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*state, err = ParseChannelState(value)
+
+	return err
+}
+
+// CanClose returns whether a channel in the state can be closed.
+func (state ChannelState) CanClose() bool {
+	return state == StateOpened
+}
+
+// CanDeposit returns whether the deposit of a channel in the state can be
+// increased.
+func (state ChannelState) CanDeposit() bool {
+	return state == StateOpened
+}
+
+// CanWithdraw returns whether tokens can be withdrawn from a channel in the state.
+func (state ChannelState) CanWithdraw() bool {
+	return state == StateOpened
+}
+
+// validateTransition returns a descriptive error if the operation, which is
+// described by a verb such as "close", is not allowed in the state of the channel.
+func validateTransition(channel *Channel, operation string, allowed bool) error {
+	if allowed {
+		return nil
+	}
+
+	return fmt.Errorf("unable to %s channel %d with partner %s: channel is %s", operation, channel.ChannelIdentifier, channel.PartnerAddress.Hex(), channel.State)
+}
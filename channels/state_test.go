@@ -0,0 +1,79 @@
+package channels
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChannelState(t *testing.T) {
+	type testcase struct {
+		name          string
+		value         string
+		expectedState ChannelState
+		expectedError error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:          "opened",
+			value:         "opened",
+			expectedState: StateOpened,
+		},
+		testcase{
+			name:          "settling",
+			value:         "settling",
+			expectedState: StateSettling,
+		},
+		testcase{
+			name:          "unusable",
+			value:         "unusable",
+			expectedState: StateUnusable,
+		},
+		testcase{
+			name:          "unknown state",
+			value:         "open",
+			expectedError: errors.New(`unknown channel state "open"`),
+		},
+		testcase{
+			name:          "empty state",
+			value:         "",
+			expectedError: errors.New(`unknown channel state ""`),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			state, err := ParseChannelState(tc.value)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedState, state)
+		})
+	}
+}
+
+func TestChannelStateUnmarshalJSON(t *testing.T) {
+	var (
+		decoded = &channel{}
+	)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"state":"closing"}`), decoded))
+	assert.Equal(t, StateClosing, decoded.State)
+
+	assert.EqualError(t, json.Unmarshal([]byte(`{"state":"exploded"}`), decoded), `unknown channel state "exploded"`)
+}
+
+func TestChannelStateTransitions(t *testing.T) {
+	for _, state := range []ChannelState{StateOpened, StateClosing, StateClosed, StateSettling, StateSettled, StateUnusable} {
+		assert.Equal(t, state == StateOpened, state.CanClose(), "close %s", state)
+		assert.Equal(t, state == StateOpened, state.CanDeposit(), "deposit %s", state)
+		assert.Equal(t, state == StateOpened, state.CanWithdraw(), "withdraw %s", state)
+	}
+}
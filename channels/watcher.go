@@ -0,0 +1,292 @@
+package channels
+
+import (
+	"context"
+	"time"
+
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// DefaultWatchInterval is the interval a Watcher lists the channels of the
+	// node if no interval is given.
+	DefaultWatchInterval = 5 * time.Second
+	// DefaultWatchMaxBackoff is the longest a Watcher waits between two listings
+	// while the node is unreachable if no maximum is given.
+	DefaultWatchMaxBackoff = time.Minute
+)
+
+// ChangeType is the kind of change of a channel reported by a Watcher.
+type ChangeType string
+
+const (
+	// ChangeOpened is reported for a channel that was not known before.
+	ChangeOpened ChangeType = "opened"
+	// ChangeDepositIncreased is reported when the total deposit of a channel
+	// increased.
+	ChangeDepositIncreased ChangeType = "deposit_increased"
+	// ChangeWithdrawn is reported when the total withdraw of a channel increased.
+	ChangeWithdrawn ChangeType = "withdrawn"
+	// ChangeBalanceChanged is reported when the balance of a channel changed for
+	// another reason than a deposit or withdraw, i.e. because of payments.
+	ChangeBalanceChanged ChangeType = "balance_changed"
+	// ChangeClosed is reported when a channel was closed, either by the node or
+	// unilaterally by the partner.
+	ChangeClosed ChangeType = "closed"
+	// ChangeSettled is reported when a channel was settled.
+	ChangeSettled ChangeType = "settled"
+	// ChangeRemoved is reported when a channel is no longer listed by the node.
+	ChangeRemoved ChangeType = "removed"
+)
+
+// Change is a change of a channel between two listings. Channel is the channel
+// as currently listed, or the last known channel for ChangeRemoved, and Previous
+// is the channel as previously listed, which is nil for ChangeOpened.
+type Change struct {
+	Type     ChangeType
+	Channel  *Channel
+	Previous *Channel
+}
+
+// WatcherOptions configures how often a Watcher lists the channels of the node.
+type WatcherOptions struct {
+	// PollInterval is the time between two listings while the node is reachable.
+	PollInterval time.Duration
+	// MaxBackoff is the maximum time between two listings while the node is
+	// unreachable. The time between listings doubles after every failed one.
+	MaxBackoff time.Duration
+}
+
+// Watcher is an interface to be notified about changes of the channels of a
+// Raiden node, either through a Go channel or through callbacks.
+type Watcher interface {
+	Watch(ctx context.Context, tokenAddress common.Address) (<-chan *Change, <-chan error)
+	WatchFunc(ctx context.Context, tokenAddress common.Address, onChange func(*Change), onError func(error)) error
+}
+
+var _ Watcher = &defaultWatcher{}
+
+// NewWatcher will create a default watcher that periodically lists channels with
+// the given lister. If options is nil the default intervals are used.
+func NewWatcher(lister Lister, options *WatcherOptions) Watcher {
+	var (
+		pollInterval = DefaultWatchInterval
+		maxBackoff   = DefaultWatchMaxBackoff
+	)
+
+	if options != nil && options.PollInterval > 0 {
+		pollInterval = options.PollInterval
+	}
+
+	if options != nil && options.MaxBackoff > 0 {
+		maxBackoff = options.MaxBackoff
+	}
+
+	return &defaultWatcher{
+		lister: lister,
+		poller: util.NewPoller(pollInterval, maxBackoff),
+	}
+}
+
+type defaultWatcher struct {
+	lister Lister
+	poller *util.Poller
+}
+
+type watchKey struct {
+	token   common.Address
+	partner common.Address
+}
+
+// Watch will list the channels of the node, or only those of the given token if
+// it is not the zero address, and send every change between two listings on the
+// returned change channel. The first listing is the baseline against which
+// changes are reported, so no changes are sent for the channels that exist when
+// watching starts. Errors while listing are sent on the error channel, which is
+// buffered and drops errors that are not received before the next one occurs.
+// Both channels are closed once the context is done.
+func (watcher *defaultWatcher) Watch(ctx context.Context, tokenAddress common.Address) (<-chan *Change, <-chan error) {
+	var (
+		changes = make(chan *Change)
+		errs    = make(chan error, 1)
+	)
+
+	go watcher.poll(ctx, tokenAddress, changes, errs)
+
+	return changes, errs
+}
+
+// WatchFunc will watch the channels like Watch and call onChange for every change
+// and onError, if it is not nil, for every error while listing. It blocks until
+// the context is done and returns the context's error.
+func (watcher *defaultWatcher) WatchFunc(ctx context.Context, tokenAddress common.Address, onChange func(*Change), onError func(error)) error {
+	changes, errs := watcher.Watch(ctx, tokenAddress)
+
+	for changes != nil || errs != nil {
+		select {
+		case change, ok := <-changes:
+			if !ok {
+				changes = nil
+				continue
+			}
+
+			onChange(change)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+
+			if onError != nil {
+				onError(err)
+			}
+		}
+	}
+
+	return ctx.Err()
+}
+
+func (watcher *defaultWatcher) poll(ctx context.Context, tokenAddress common.Address, changes chan<- *Change, errs chan<- error) {
+	var (
+		snapshot []*Channel
+		baseline = true
+	)
+
+	defer close(changes)
+	defer close(errs)
+
+	watcher.poller.Poll(ctx, func() (bool, error) {
+		current, err := watcher.list(ctx, tokenAddress)
+
+		if err != nil {
+			return false, err
+		}
+
+		if !baseline {
+			for _, change := range diffChannels(snapshot, current) {
+				select {
+				case <-ctx.Done():
+					return false, nil
+				case changes <- change:
+				}
+			}
+		}
+
+		snapshot = current
+		baseline = false
+
+		return false, nil
+	}, func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	})
+}
+
+func (watcher *defaultWatcher) list(ctx context.Context, tokenAddress common.Address) ([]*Channel, error) {
+	if tokenAddress == (common.Address{}) {
+		return watcher.lister.List(ctx)
+	}
+
+	return watcher.lister.ListByToken(ctx, tokenAddress)
+}
+
+// diffChannels returns the changes between two listings of channels, in the
+// order of the current listing followed by the removed channels. A channel that
+// was reopened with the same partner after having been settled is reported as
+// removed and opened.
+func diffChannels(previous, current []*Channel) []*Change {
+	var (
+		changes = make([]*Change, 0)
+		known   = make(map[watchKey]*Channel, len(previous))
+		listed  = make(map[watchKey]*Channel, len(current))
+	)
+
+	for _, channel := range previous {
+		known[watchKey{token: channel.TokenAddress, partner: channel.PartnerAddress}] = channel
+	}
+
+	for _, channel := range current {
+		key := watchKey{token: channel.TokenAddress, partner: channel.PartnerAddress}
+		listed[key] = channel
+
+		before, ok := known[key]
+
+		if ok && before.ChannelIdentifier != channel.ChannelIdentifier {
+			changes = append(changes, &Change{Type: ChangeRemoved, Channel: before, Previous: before})
+			ok = false
+		}
+
+		if !ok {
+			changes = append(changes, &Change{Type: ChangeOpened, Channel: channel})
+			changes = append(changes, stateChanges(&Channel{State: StateOpened}, channel)...)
+			continue
+		}
+
+		changes = append(changes, channelChanges(before, channel)...)
+	}
+
+	for _, channel := range previous {
+		key := watchKey{token: channel.TokenAddress, partner: channel.PartnerAddress}
+
+		// a channel replaced by a new one with the same partner was already
+		// reported as removed above
+		if _, ok := listed[key]; !ok {
+			changes = append(changes, &Change{Type: ChangeRemoved, Channel: channel, Previous: channel})
+		}
+	}
+
+	return changes
+}
+
+// channelChanges returns the changes of the deposit, withdraw, balance and state
+// of the same channel between two listings.
+func channelChanges(before, after *Channel) []*Change {
+	var (
+		changes         = make([]*Change, 0)
+		depositDelta    = after.TotalDeposit.Sub(before.TotalDeposit)
+		withdrawDelta   = after.TotalWithdraw.Sub(before.TotalWithdraw)
+		expectedBalance = before.Balance.Add(depositDelta).Sub(withdrawDelta)
+	)
+
+	if depositDelta.Sign() > 0 {
+		changes = append(changes, &Change{Type: ChangeDepositIncreased, Channel: after, Previous: before})
+	}
+
+	if withdrawDelta.Sign() > 0 {
+		changes = append(changes, &Change{Type: ChangeWithdrawn, Channel: after, Previous: before})
+	}
+
+	if after.Balance.Cmp(expectedBalance) != 0 {
+		changes = append(changes, &Change{Type: ChangeBalanceChanged, Channel: after, Previous: before})
+	}
+
+	return append(changes, stateChanges(before, after)...)
+}
+
+// stateChanges returns the state changes of a channel. The pending closing and
+// settling states are not reported themselves, and a channel that went from
+// opened to settled between two listings is reported as closed and settled.
+func stateChanges(before, after *Channel) []*Change {
+	var (
+		changes  = make([]*Change, 0)
+		wasOpen  = before.State == StateOpened || before.State == StateClosing
+		isClosed = after.State == StateClosed || after.State == StateSettling || after.State == StateSettled
+	)
+
+	if before.State == after.State {
+		return changes
+	}
+
+	if wasOpen && isClosed {
+		changes = append(changes, &Change{Type: ChangeClosed, Channel: after, Previous: before})
+	}
+
+	if after.State == StateSettled {
+		changes = append(changes, &Change{Type: ChangeSettled, Channel: after, Previous: before})
+	}
+
+	return changes
+}
@@ -0,0 +1,292 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleWatcher() {
+	var (
+		channelClient *Client
+		config        = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		tokenAddress = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359") // DAI Stablecoin
+		ctx, cancel  = context.WithCancel(context.Background())
+	)
+
+	defer cancel()
+
+	channelClient = NewClient(config, http.DefaultClient)
+
+	onChange := func(change *Change) {
+		if change.Type == ChangeClosed {
+			fmt.Printf("channel %d with %s was closed\n", change.Channel.ChannelIdentifier, change.Channel.PartnerAddress.Hex())
+		}
+	}
+
+	onError := func(err error) {
+		fmt.Println("unable to list channels:", err)
+	}
+
+	if err := channelClient.WatchFunc(ctx, tokenAddress, onChange, onError); err != nil {
+		fmt.Println("stopped watching channels:", err)
+	}
+}
+
+type scriptedResult struct {
+	channels []*Channel
+	err      error
+}
+
+type scriptedLister struct {
+	mutex   sync.Mutex
+	results []scriptedResult
+	tokens  []common.Address
+}
+
+func (lister *scriptedLister) next() ([]*Channel, error) {
+	lister.mutex.Lock()
+	defer lister.mutex.Unlock()
+
+	if len(lister.results) == 0 {
+		return nil, errors.New("no more results")
+	}
+
+	result := lister.results[0]
+
+	if len(lister.results) > 1 {
+		lister.results = lister.results[1:]
+	}
+
+	return result.channels, result.err
+}
+
+func (lister *scriptedLister) List(ctx context.Context) ([]*Channel, error) {
+	lister.mutex.Lock()
+	lister.tokens = append(lister.tokens, common.Address{})
+	lister.mutex.Unlock()
+
+	return lister.next()
+}
+
+func (lister *scriptedLister) ListByToken(ctx context.Context, tokenAddress common.Address) ([]*Channel, error) {
+	lister.mutex.Lock()
+	lister.tokens = append(lister.tokens, tokenAddress)
+	lister.mutex.Unlock()
+
+	return lister.next()
+}
+
+func testChannel(identifier int64, partner string, balance, deposit, withdraw int64, state ChannelState) *Channel {
+	return &Channel{
+		ChannelIdentifier: identifier,
+		PartnerAddress:    common.HexToAddress(partner),
+		TokenAddress:      common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"),
+		Balance:           util.NewAmount(balance),
+		TotalDeposit:      util.NewAmount(deposit),
+		TotalWithdraw:     util.NewAmount(withdraw),
+		State:             state,
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	var (
+		tokenAddress = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+		opened       = testChannel(1, "0x61C808D82A3Ac53231750daDc13c777b59310bD9", 100, 100, 0, "opened")
+		deposited    = testChannel(1, "0x61C808D82A3Ac53231750daDc13c777b59310bD9", 150, 150, 0, "opened")
+		closed       = testChannel(1, "0x61C808D82A3Ac53231750daDc13c777b59310bD9", 150, 150, 0, "closed")
+
+		lister = &scriptedLister{
+			results: []scriptedResult{
+				scriptedResult{channels: []*Channel{opened}},
+				scriptedResult{err: errors.New("connection refused")},
+				scriptedResult{channels: []*Channel{deposited}},
+				scriptedResult{channels: []*Channel{closed}},
+			},
+		}
+		watcher = NewWatcher(lister, &WatcherOptions{
+			PollInterval: time.Millisecond,
+			MaxBackoff:   5 * time.Millisecond,
+		})
+		ctx, cancel = context.WithCancel(context.Background())
+		received    = make([]*Change, 0)
+	)
+
+	changes, errs := watcher.Watch(ctx, tokenAddress)
+
+	for len(received) < 2 {
+		select {
+		case change := <-changes:
+			received = append(received, change)
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for channel changes")
+		}
+	}
+
+	assert.Equal(t, []*Change{
+		&Change{Type: ChangeDepositIncreased, Channel: deposited, Previous: opened},
+		&Change{Type: ChangeClosed, Channel: closed, Previous: deposited},
+	}, received)
+	assert.EqualError(t, <-errs, "connection refused")
+
+	cancel()
+
+	for range changes {
+	}
+
+	_, ok := <-errs
+	assert.False(t, ok)
+
+	lister.mutex.Lock()
+	defer lister.mutex.Unlock()
+
+	assert.Equal(t, tokenAddress, lister.tokens[0])
+}
+
+func TestWatchFunc(t *testing.T) {
+	var (
+		opened  = testChannel(1, "0x61C808D82A3Ac53231750daDc13c777b59310bD9", 100, 100, 0, "opened")
+		settled = testChannel(1, "0x61C808D82A3Ac53231750daDc13c777b59310bD9", 100, 100, 0, "settled")
+
+		lister = &scriptedLister{
+			results: []scriptedResult{
+				scriptedResult{channels: []*Channel{}},
+				scriptedResult{channels: []*Channel{opened}},
+				scriptedResult{channels: []*Channel{settled}},
+			},
+		}
+		watcher     = NewWatcher(lister, &WatcherOptions{PollInterval: time.Millisecond})
+		ctx, cancel = context.WithCancel(context.Background())
+		received    = make([]ChangeType, 0)
+	)
+
+	err := watcher.WatchFunc(ctx, common.Address{}, func(change *Change) {
+		if received = append(received, change.Type); len(received) == 3 {
+			cancel()
+		}
+	}, nil)
+
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []ChangeType{ChangeOpened, ChangeClosed, ChangeSettled}, received)
+
+	lister.mutex.Lock()
+	defer lister.mutex.Unlock()
+
+	assert.Equal(t, common.Address{}, lister.tokens[0])
+}
+
+func TestDiffChannels(t *testing.T) {
+	var (
+		partner1 = "0x61C808D82A3Ac53231750daDc13c777b59310bD9"
+		partner2 = "0x2a65Aca4D5fC5B5C859090a6c34d164135398226"
+	)
+
+	type testcase struct {
+		name     string
+		previous []*Channel
+		current  []*Channel
+		expected []ChangeType
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:     "no changes",
+			previous: []*Channel{testChannel(1, partner1, 100, 100, 0, "opened")},
+			current:  []*Channel{testChannel(1, partner1, 100, 100, 0, "opened")},
+			expected: []ChangeType{},
+		},
+		testcase{
+			name:     "channel opened",
+			previous: []*Channel{},
+			current:  []*Channel{testChannel(1, partner1, 100, 100, 0, "opened")},
+			expected: []ChangeType{ChangeOpened},
+		},
+		testcase{
+			name:     "channel opened and closed between listings",
+			previous: []*Channel{},
+			current:  []*Channel{testChannel(1, partner1, 100, 100, 0, "closed")},
+			expected: []ChangeType{ChangeOpened, ChangeClosed},
+		},
+		testcase{
+			name:     "deposit increased",
+			previous: []*Channel{testChannel(1, partner1, 100, 100, 0, "opened")},
+			current:  []*Channel{testChannel(1, partner1, 150, 150, 0, "opened")},
+			expected: []ChangeType{ChangeDepositIncreased},
+		},
+		testcase{
+			name:     "withdrawn",
+			previous: []*Channel{testChannel(1, partner1, 100, 100, 0, "opened")},
+			current:  []*Channel{testChannel(1, partner1, 70, 100, 30, "opened")},
+			expected: []ChangeType{ChangeWithdrawn},
+		},
+		testcase{
+			name:     "balance changed by payments",
+			previous: []*Channel{testChannel(1, partner1, 100, 100, 0, "opened")},
+			current:  []*Channel{testChannel(1, partner1, 80, 100, 0, "opened")},
+			expected: []ChangeType{ChangeBalanceChanged},
+		},
+		testcase{
+			name:     "deposit increased and payments made",
+			previous: []*Channel{testChannel(1, partner1, 100, 100, 0, "opened")},
+			current:  []*Channel{testChannel(1, partner1, 140, 150, 0, "opened")},
+			expected: []ChangeType{ChangeDepositIncreased, ChangeBalanceChanged},
+		},
+		testcase{
+			name:     "closed",
+			previous: []*Channel{testChannel(1, partner1, 100, 100, 0, "opened")},
+			current:  []*Channel{testChannel(1, partner1, 100, 100, 0, "closed")},
+			expected: []ChangeType{ChangeClosed},
+		},
+		testcase{
+			name:     "settled",
+			previous: []*Channel{testChannel(1, partner1, 100, 100, 0, "closed")},
+			current:  []*Channel{testChannel(1, partner1, 100, 100, 0, "settled")},
+			expected: []ChangeType{ChangeSettled},
+		},
+		testcase{
+			name:     "closed and settled between listings",
+			previous: []*Channel{testChannel(1, partner1, 100, 100, 0, "opened")},
+			current:  []*Channel{testChannel(1, partner1, 100, 100, 0, "settled")},
+			expected: []ChangeType{ChangeClosed, ChangeSettled},
+		},
+		testcase{
+			name:     "removed",
+			previous: []*Channel{testChannel(1, partner1, 100, 100, 0, "settled"), testChannel(2, partner2, 10, 10, 0, "opened")},
+			current:  []*Channel{testChannel(2, partner2, 10, 10, 0, "opened")},
+			expected: []ChangeType{ChangeRemoved},
+		},
+		testcase{
+			name:     "reopened with the same partner",
+			previous: []*Channel{testChannel(1, partner1, 100, 100, 0, "settled")},
+			current:  []*Channel{testChannel(3, partner1, 50, 50, 0, "opened")},
+			expected: []ChangeType{ChangeRemoved, ChangeOpened},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			changes := diffChannels(tc.previous, tc.current)
+
+			types := make([]ChangeType, 0, len(changes))
+
+			for _, change := range changes {
+				types = append(types, change.Type)
+			}
+
+			assert.Equal(t, tc.expected, types)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type withdrawRequest struct {
+	TotalWithdraw *util.Amount `json:"total_withdraw"`
+}
+
+// Withdrawer represents a generic interface to withdraw funds from an open Payment
+// Channel given a token and partner address. Like deposits, withdraws are given as
+// the new total amount withdrawn from the channel.
+type Withdrawer interface {
+	Withdraw(ctx context.Context, tokenAddress, partnerAddress common.Address, totalWithdraw *util.Amount) (*Channel, error)
+}
+
+var _ Withdrawer = &defaultWithdrawer{}
+
+// NewWithdrawer creates a new default Channel withdrawer given a Raiden node configuration
+// and an http client.
+func NewWithdrawer(config *config.Config, httpClient *http.Client) Withdrawer {
+	return &defaultWithdrawer{
+		baseClient: &util.BaseClient{
+			Config:     config,
+			HTTPClient: httpClient,
+		},
+		getter: NewGetter(config, httpClient),
+	}
+}
+
+type defaultWithdrawer struct {
+	baseClient *util.BaseClient
+	getter     Getter
+}
+
+// Withdraw will set the total amount withdrawn from a payment channel given a token
+// address and a partner address. The current channel is fetched first so that a
+// withdraw from a channel that is not open or exceeding the withdrawable balance
+// is rejected before reaching the node.
+func (withdrawer *defaultWithdrawer) Withdraw(ctx context.Context, tokenAddress, partnerAddress common.Address, totalWithdraw *util.Amount) (*Channel, error) {
+	var (
+		err             error
+		current         *Channel
+		channel         = &channel{}
+		withdrawRequest = &withdrawRequest{
+			TotalWithdraw: totalWithdraw,
+		}
+	)
+
+	if current, err = withdrawer.getter.Get(ctx, tokenAddress, partnerAddress); err != nil {
+		return nil, err
+	}
+
+	if err = validateTransition(current, "withdraw from", current.State.CanWithdraw()); err != nil {
+		return nil, err
+	}
+
+	if err = validateWithdraw(current, totalWithdraw); err != nil {
+		return nil, err
+	}
+
+	if err = withdrawer.baseClient.Do(ctx, "PATCH", []string{"channels", tokenAddress.Hex(), partnerAddress.Hex()}, withdrawRequest, channel); err != nil {
+		return nil, err
+	}
+
+	return channel.toChannel(), nil
+}
+
+// validateWithdraw checks that the new total withdraw increases the amount already
+// withdrawn from the channel and that the increase is covered by the balance.
+func validateWithdraw(channel *Channel, totalWithdraw *util.Amount) error {
+	var (
+		withdrawAmount = totalWithdraw.Sub(channel.TotalWithdraw)
+	)
+
+	if withdrawAmount.Sign() <= 0 {
+		return fmt.Errorf("total withdraw %s must be greater than the current total withdraw %s", totalWithdraw, channel.TotalWithdraw)
+	}
+
+	if withdrawAmount.Cmp(channel.Balance) > 0 {
+		return fmt.Errorf("withdraw amount %s exceeds the withdrawable balance %s", withdrawAmount, channel.Balance)
+	}
+
+	return nil
+}
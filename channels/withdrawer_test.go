@@ -0,0 +1,205 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleWithdrawer() {
+	var (
+		channelClient *Client
+		config        = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		tokenAddress   = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359") // DAI Stablecoin
+		partnerAddress = common.HexToAddress("0x1f7402f55e142820ea3812106d0657103fc1709e")
+		totalWithdraw  = util.NewAmount(500)
+		channel        *Channel
+		err            error
+	)
+
+	channelClient = NewClient(config, http.DefaultClient)
+
+	if channel, err = channelClient.Withdraw(context.Background(), tokenAddress, partnerAddress, totalWithdraw); err != nil {
+		panic(fmt.Sprintf("unable to withdraw from payment channel: %s", err.Error()))
+	}
+
+	fmt.Printf("Channel Info: %+v\n", channel)
+}
+
+func TestWithdrawer(t *testing.T) {
+	var (
+		localhostIP = "[::1]"
+		config      = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		currentChannel = `{"token_network_identifier":"0xE5637F0103794C7e05469A9964E4563089a5E6f2","channel_identifier":20,"partner_address":"0x61C808D82A3Ac53231750daDc13c777b59310bD9","token_address":"0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8","balance":25000000,"total_deposit":35000000,"total_withdraw":10000000,"state":"opened","settle_timeout":500,"reveal_timeout":30}`
+	)
+
+	if os.Getenv("USE_IPV4") != "" {
+		localhostIP = "127.0.0.1"
+	}
+
+	type testcase struct {
+		name            string
+		prepHTTPMock    func()
+		totalWithdraw   *util.Amount
+		expectedChannel *Channel
+		expectedError   error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "successfully withdrew from payment channel",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						currentChannel,
+					),
+				)
+
+				httpmock.RegisterResponder(
+					"PATCH",
+					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						`{"token_network_identifier":"0xE5637F0103794C7e05469A9964E4563089a5E6f2","channel_identifier":20,"partner_address":"0x61C808D82A3Ac53231750daDc13c777b59310bD9","token_address":"0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8","balance":15000000,"total_deposit":35000000,"total_withdraw":20000000,"state":"opened","settle_timeout":500,"reveal_timeout":30}`,
+					),
+				)
+			},
+			totalWithdraw: util.NewAmount(20000000),
+			expectedError: nil,
+			expectedChannel: &Channel{
+				TokenNetworkIdentifier: common.HexToAddress("0xE5637F0103794C7e05469A9964E4563089a5E6f2"),
+				ChannelIdentifier:      int64(20),
+				PartnerAddress:         common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9"),
+				TokenAddress:           common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"),
+				Balance:                util.NewAmount(15000000),
+				TotalDeposit:           util.NewAmount(35000000),
+				TotalWithdraw:          util.NewAmount(20000000),
+				State:                  "opened",
+				SettleTimeout:          int64(500),
+				RevealTimeout:          int64(30),
+			},
+		},
+		testcase{
+			name: "withdraw exceeds withdrawable balance",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						currentChannel,
+					),
+				)
+			},
+			totalWithdraw:   util.NewAmount(40000000),
+			expectedError:   errors.New("withdraw amount 30000000 exceeds the withdrawable balance 25000000"),
+			expectedChannel: nil,
+		},
+		testcase{
+			name: "total withdraw does not increase",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						currentChannel,
+					),
+				)
+			},
+			totalWithdraw:   util.NewAmount(10000000),
+			expectedError:   errors.New("total withdraw 10000000 must be greater than the current total withdraw 10000000"),
+			expectedChannel: nil,
+		},
+		testcase{
+			name: "channel is not open",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						strings.Replace(currentChannel, `"opened"`, `"settled"`, 1),
+					),
+				)
+			},
+			totalWithdraw:   util.NewAmount(20000000),
+			expectedError:   errors.New("unable to withdraw from channel 20 with partner 0x61C808D82A3Ac53231750daDc13c777b59310bD9: channel is settled"),
+			expectedChannel: nil,
+		},
+		testcase{
+			name: "unexpected 500 response",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					httpmock.NewStringResponder(
+						http.StatusInternalServerError,
+						``,
+					),
+				)
+			},
+			totalWithdraw:   util.NewAmount(20000000),
+			expectedError:   &util.APIError{StatusCode: http.StatusInternalServerError, Method: "GET", URL: "http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9"},
+			expectedChannel: nil,
+		},
+		testcase{
+			name: "unable to make http request",
+			prepHTTPMock: func() {
+				httpmock.Deactivate()
+			},
+			totalWithdraw:   util.NewAmount(20000000),
+			expectedError:   fmt.Errorf("Get http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/0x61C808D82A3Ac53231750daDc13c777b59310bD9: dial tcp %s:5001: connect: connection refused", localhostIP),
+			expectedChannel: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				err            error
+				channel        *Channel
+				tokenAddress   = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+				partnerAddress = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
+
+				withdrawer = NewWithdrawer(config, http.DefaultClient)
+				ctx        = context.Background()
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			tc.prepHTTPMock()
+
+			channel, err = withdrawer.Withdraw(ctx, tokenAddress, partnerAddress, tc.totalWithdraw)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedChannel, channel)
+		})
+	}
+}
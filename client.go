@@ -9,9 +9,13 @@ import (
 	"github.com/cpurta/go-raiden-client/channels"
 	"github.com/cpurta/go-raiden-client/config"
 	"github.com/cpurta/go-raiden-client/connections"
+	"github.com/cpurta/go-raiden-client/node"
+	"github.com/cpurta/go-raiden-client/pathfinding"
 	"github.com/cpurta/go-raiden-client/payments"
 	"github.com/cpurta/go-raiden-client/pending_transfers"
+	"github.com/cpurta/go-raiden-client/testnet"
 	"github.com/cpurta/go-raiden-client/tokens"
+	"github.com/cpurta/go-raiden-client/userdeposit"
 )
 
 // NewClient will return a Raiden client that is able to access all of the API
@@ -25,9 +29,33 @@ func NewClient(config *config.Config, httpClient *http.Client) *Client {
 		PaymentsClient:         payments.NewClient(config, httpClient),
 		ConnectionsClient:      connections.NewClient(config, httpClient),
 		PendingTransfersClient: pendingtransfers.NewClient(config, httpClient),
+		NodeClient:             node.NewClient(config, httpClient),
+		UserDepositClient:      userdeposit.NewClient(config, httpClient),
 	}
 }
 
+// NewTestnetClient will return a Raiden client like NewClient that additionally
+// has access to the testing endpoints of nodes running in a development
+// environment, such as minting test tokens. Creating the client with it is the
+// explicit opt-in to those endpoints, which production nodes do not serve.
+func NewTestnetClient(config *config.Config, httpClient *http.Client) *Client {
+	client := NewClient(config, httpClient)
+	client.TestnetClient = testnet.NewClient(config, httpClient)
+
+	return client
+}
+
+// WithPathfinding sets up the Pathfinding sub-client for the pathfinding service
+// in the given config, whose API version is the version of the service's API, and
+// the fee capped initiator of the Payments sub-client, which quotes fees with it.
+// It returns the client.
+func (client *Client) WithPathfinding(pathfindingConfig *config.Config, httpClient *http.Client) *Client {
+	client.PathfindingClient = pathfinding.NewClient(pathfindingConfig, httpClient, client.TokensClient, client.AddressClient)
+	client.PaymentsClient.WithFeeQuoter(client.PathfindingClient)
+
+	return client
+}
+
 // Client provides access to API sub-clients that correspond to the various API
 // calls that a Raiden node supports.
 type Client struct {
@@ -37,6 +65,10 @@ type Client struct {
 	PaymentsClient         *payments.Client
 	ConnectionsClient      *connections.Client
 	PendingTransfersClient *pendingtransfers.Client
+	NodeClient             *node.Client
+	UserDepositClient      *userdeposit.Client
+	TestnetClient          *testnet.Client
+	PathfindingClient      *pathfinding.Client
 }
 
 // Address returns the Address sub-client to access the address being used by the
@@ -51,14 +83,15 @@ func (client *Client) Tokens() *tokens.Client {
 	return client.TokensClient
 }
 
-// Channels returns the Channels sub-client that will be able to open, close and
-// increase the deposit of a micro-payment channel.
+// Channels returns the Channels sub-client that will be able to open, close,
+// increase the deposit of, withdraw from, list, get and watch micro-payment
+// channels.
 func (client *Client) Channels() *channels.Client {
 	return client.ChannelsClient
 }
 
-// Payments returns the Payments sub-client that will be able to query all of the
-// pending payments.
+// Payments returns the Payments sub-client that will be able to initiate payments,
+// query payment events and subscribe to new payment events.
 func (client *Client) Payments() *payments.Client {
 	return client.PaymentsClient
 }
@@ -74,3 +107,30 @@ func (client *Client) Connections() *connections.Client {
 func (client *Client) PendingTransfers() *pendingtransfers.Client {
 	return client.PendingTransfersClient
 }
+
+// Node returns the Node sub-client that will be able to get the status, version
+// and settings of the Raiden node and wait until the node is ready.
+func (client *Client) Node() *node.Client {
+	return client.NodeClient
+}
+
+// UserDeposit returns the UserDeposit sub-client that will be able to get, deposit
+// into, plan withdrawals from and withdraw from the node's balance in the
+// UserDeposit contract.
+func (client *Client) UserDeposit() *userdeposit.Client {
+	return client.UserDepositClient
+}
+
+// Testnet returns the Testnet sub-client that will be able to mint test tokens and
+// join token networks with them. It is nil unless the client was created with
+// NewTestnetClient.
+func (client *Client) Testnet() *testnet.Client {
+	return client.TestnetClient
+}
+
+// Pathfinding returns the Pathfinding sub-client that will be able to quote the
+// routes and fees of payments. It is nil unless the client was set up with
+// WithPathfinding.
+func (client *Client) Pathfinding() *pathfinding.Client {
+	return client.PathfindingClient
+}
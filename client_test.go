@@ -4,9 +4,13 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"testing"
 
 	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/payments"
+	"github.com/cpurta/go-raiden-client/util"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
 )
 
 func Example() {
@@ -29,3 +33,41 @@ func Example() {
 
 	log.Println("raiden token address:", address.Hex())
 }
+
+func TestNewTestnetClient(t *testing.T) {
+	raidenConfig := &config.Config{
+		Host:       "http://localhost:5001",
+		APIVersion: "v1",
+	}
+
+	// the testing endpoints are only available after opting in
+	assert.Nil(t, NewClient(raidenConfig, http.DefaultClient).Testnet())
+	assert.NotNil(t, NewTestnetClient(raidenConfig, http.DefaultClient).Testnet())
+}
+
+func TestWithPathfinding(t *testing.T) {
+	var (
+		raidenConfig = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		pathfindingConfig = &config.Config{
+			Host:       "https://pfs.raiden.network",
+			APIVersion: "v1",
+		}
+	)
+
+	raidenClient := NewClient(raidenConfig, http.DefaultClient)
+
+	assert.Nil(t, raidenClient.Pathfinding())
+
+	// fee capped payments fail instead of panicking without a pathfinding client
+	_, err := raidenClient.Payments().InitiateFeeCapped(context.Background(), common.Address{}, common.Address{}, util.NewAmount(1000), &payments.FeeCappedOptions{MaxFee: util.NewAmount(50)})
+	assert.EqualError(t, err, "no fee quoter configured; use WithFeeQuoter or WithPathfinding")
+
+	// fee capped payments quote their fees with the pathfinding sub-client
+	raidenClient = raidenClient.WithPathfinding(pathfindingConfig, http.DefaultClient)
+
+	assert.NotNil(t, raidenClient.Pathfinding())
+	assert.NotNil(t, raidenClient.Payments().FeeCappedInitiator)
+}
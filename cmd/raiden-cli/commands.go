@@ -0,0 +1,601 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cpurta/go-raiden-client/channels"
+	"github.com/cpurta/go-raiden-client/payments"
+	"github.com/cpurta/go-raiden-client/pending_transfers"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// command is a subcommand identified by one or more words, e.g. "tokens list".
+type command struct {
+	name  []string
+	usage string
+	run   func(cli *cli, args []string) error
+}
+
+var commands = []*command{
+	&command{name: []string{"address"}, usage: "address", run: runAddress},
+	&command{name: []string{"tokens", "list"}, usage: "tokens list", run: runTokensList},
+	&command{name: []string{"tokens", "get"}, usage: "tokens get <token>", run: runTokensGet},
+	&command{name: []string{"tokens", "register"}, usage: "tokens register <token>", run: runTokensRegister},
+	&command{name: []string{"tokens", "partners"}, usage: "tokens partners <token>", run: runTokensPartners},
+	&command{name: []string{"channels", "list"}, usage: "channels list [token]", run: runChannelsList},
+	&command{name: []string{"channels", "open"}, usage: "channels open [-settle-timeout blocks] <token> <partner> <deposit>", run: runChannelsOpen},
+	&command{name: []string{"channels", "close"}, usage: "channels close <token> <partner>", run: runChannelsClose},
+	&command{name: []string{"channels", "deposit"}, usage: "channels deposit <token> <partner> <total deposit>", run: runChannelsDeposit},
+	&command{name: []string{"payments", "send"}, usage: "payments send [-identifier id] [-key idempotency key] <token> <target> <amount>", run: runPaymentsSend},
+	&command{name: []string{"payments", "history"}, usage: "payments history [-limit n] [-offset n] [token [target]]", run: runPaymentsHistory},
+	&command{name: []string{"connections", "list"}, usage: "connections list", run: runConnectionsList},
+	&command{name: []string{"connections", "join"}, usage: "connections join <token> <funds>", run: runConnectionsJoin},
+	&command{name: []string{"connections", "leave"}, usage: "connections leave <token>", run: runConnectionsLeave},
+	&command{name: []string{"pending", "list"}, usage: "pending list [token [partner]]", run: runPendingList},
+}
+
+// dispatch runs the command named by the leading arguments.
+func (cli *cli) dispatch(args []string) error {
+	for _, command := range commands {
+		if len(args) < len(command.name) || strings.Join(args[:len(command.name)], " ") != strings.Join(command.name, " ") {
+			continue
+		}
+
+		return command.run(cli, args[len(command.name):])
+	}
+
+	return newUsageError("unknown command %q", strings.Join(args, " "))
+}
+
+func runAddress(cli *cli, args []string) error {
+	if err := expectArgs(args, 0, 0); err != nil {
+		return err
+	}
+
+	address, err := cli.client.Address().Get(cli.ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return cli.printer.print(map[string]common.Address{"address": address}, []string{"ADDRESS"}, [][]string{{address.Hex()}})
+}
+
+func runTokensList(cli *cli, args []string) error {
+	if err := expectArgs(args, 0, 0); err != nil {
+		return err
+	}
+
+	tokens, err := cli.client.Tokens().List(cli.ctx)
+
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(tokens))
+
+	for _, token := range tokens {
+		rows = append(rows, []string{token.Hex()})
+	}
+
+	return cli.printer.print(tokens, []string{"TOKEN"}, rows)
+}
+
+func runTokensGet(cli *cli, args []string) error {
+	addresses, err := parseAddresses(args, "token")
+
+	if err != nil {
+		return err
+	}
+
+	network, err := cli.client.Tokens().Get(cli.ctx, addresses[0])
+
+	if err != nil {
+		return err
+	}
+
+	return printTokenNetwork(cli, addresses[0], network)
+}
+
+func runTokensRegister(cli *cli, args []string) error {
+	addresses, err := parseAddresses(args, "token")
+
+	if err != nil {
+		return err
+	}
+
+	network, err := cli.client.Tokens().Register(cli.ctx, addresses[0])
+
+	if err != nil {
+		return err
+	}
+
+	return printTokenNetwork(cli, addresses[0], network)
+}
+
+func printTokenNetwork(cli *cli, token, network common.Address) error {
+	return cli.printer.print(
+		map[string]common.Address{"token_address": token, "token_network_address": network},
+		[]string{"TOKEN", "NETWORK"},
+		[][]string{{token.Hex(), network.Hex()}},
+	)
+}
+
+func runTokensPartners(cli *cli, args []string) error {
+	addresses, err := parseAddresses(args, "token")
+
+	if err != nil {
+		return err
+	}
+
+	partners, err := cli.client.Tokens().ListPartners(cli.ctx, addresses[0])
+
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(partners))
+
+	for _, partner := range partners {
+		rows = append(rows, []string{partner.Address.Hex(), partner.ChannelURI})
+	}
+
+	return cli.printer.print(partners, []string{"PARTNER", "CHANNEL"}, rows)
+}
+
+func runChannelsList(cli *cli, args []string) error {
+	var (
+		err      error
+		channels []*channels.Channel
+	)
+
+	if err = expectArgs(args, 0, 1); err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		channels, err = cli.client.Channels().List(cli.ctx)
+	} else {
+		var addresses []common.Address
+
+		if addresses, err = parseAddresses(args, "token"); err != nil {
+			return err
+		}
+
+		channels, err = cli.client.Channels().ListByToken(cli.ctx, addresses[0])
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return printChannels(cli, channels)
+}
+
+func runChannelsOpen(cli *cli, args []string) error {
+	var (
+		flags         = newFlagSet("channels open")
+		settleTimeout = flags.Int64("settle-timeout", 0, "settle timeout in blocks, left to the node default if 0")
+	)
+
+	if err := flags.Parse(args); err != nil {
+		return newUsageError("%s", err.Error())
+	}
+
+	addresses, err := parseAddresses(flags.Args(), "token", "partner", "")
+
+	if err != nil {
+		return err
+	}
+
+	deposit, err := parseAmount("deposit", flags.Arg(2))
+
+	if err != nil {
+		return err
+	}
+
+	channel, err := cli.client.Channels().Open(cli.ctx, addresses[0], addresses[1], deposit, *settleTimeout)
+
+	if err != nil {
+		return err
+	}
+
+	return printChannel(cli, channel)
+}
+
+func runChannelsClose(cli *cli, args []string) error {
+	addresses, err := parseAddresses(args, "token", "partner")
+
+	if err != nil {
+		return err
+	}
+
+	channel, err := cli.client.Channels().Close(cli.ctx, addresses[0], addresses[1])
+
+	if err != nil {
+		return err
+	}
+
+	return printChannel(cli, channel)
+}
+
+func runChannelsDeposit(cli *cli, args []string) error {
+	addresses, err := parseAddresses(args, "token", "partner", "")
+
+	if err != nil {
+		return err
+	}
+
+	deposit, err := parseAmount("total deposit", args[2])
+
+	if err != nil {
+		return err
+	}
+
+	channel, err := cli.client.Channels().IncreaseDeposit(cli.ctx, addresses[0], addresses[1], deposit)
+
+	if err != nil {
+		return err
+	}
+
+	return printChannel(cli, channel)
+}
+
+var channelHeader = []string{"TOKEN", "PARTNER", "ID", "STATE", "BALANCE", "DEPOSIT", "WITHDRAWN"}
+
+// printChannel prints a single channel as a table or as a JSON object.
+func printChannel(cli *cli, channel *channels.Channel) error {
+	return cli.printer.print(newChannelOutput(channel), channelHeader, channelRows(channel))
+}
+
+// printChannels prints the channels as a table or as a JSON list.
+func printChannels(cli *cli, channels []*channels.Channel) error {
+	outputs := make([]*channelOutput, 0, len(channels))
+
+	for _, channel := range channels {
+		outputs = append(outputs, newChannelOutput(channel))
+	}
+
+	return cli.printer.print(outputs, channelHeader, channelRows(channels...))
+}
+
+func channelRows(channels ...*channels.Channel) [][]string {
+	rows := make([][]string, 0, len(channels))
+
+	for _, channel := range channels {
+		rows = append(rows, []string{
+			channel.TokenAddress.Hex(),
+			channel.PartnerAddress.Hex(),
+			strconv.FormatInt(channel.ChannelIdentifier, 10),
+			channel.State.String(),
+			channel.Balance.String(),
+			channel.TotalDeposit.String(),
+			channel.TotalWithdraw.String(),
+		})
+	}
+
+	return rows
+}
+
+func runPaymentsSend(cli *cli, args []string) error {
+	var (
+		err        error
+		payment    *payments.Payment
+		flags      = newFlagSet("payments send")
+		identifier = flags.Uint64("identifier", 0, "payment identifier, generated by the node if 0")
+		key        = flags.String("key", "", "idempotency key the payment identifier is derived from")
+	)
+
+	if err = flags.Parse(args); err != nil {
+		return newUsageError("%s", err.Error())
+	}
+
+	addresses, err := parseAddresses(flags.Args(), "token", "target", "")
+
+	if err != nil {
+		return err
+	}
+
+	amount, err := parseAmount("amount", flags.Arg(2))
+
+	if err != nil {
+		return err
+	}
+
+	options := &payments.InitiateOptions{
+		Identifier: *identifier,
+	}
+
+	if *key == "" {
+		payment, err = cli.client.Payments().Initiate(cli.ctx, addresses[0], addresses[1], amount, options)
+	} else {
+		var result *payments.PaymentResult
+
+		if result, err = cli.client.Payments().Pay(cli.ctx, *key, addresses[0], addresses[1], amount, options); err == nil {
+			payment = result.Payment
+
+			if payment == nil {
+				payment = &payments.Payment{
+					TargetAddress: addresses[1],
+					TokenAddress:  addresses[0],
+					Amount:        result.Event.Amount,
+					Identifier:    result.Identifier,
+				}
+			}
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return cli.printer.print(
+		payment,
+		[]string{"TOKEN", "TARGET", "AMOUNT", "IDENTIFIER"},
+		[][]string{{payment.TokenAddress.Hex(), payment.TargetAddress.Hex(), payment.Amount.String(), strconv.FormatUint(payment.Identifier, 10)}},
+	)
+}
+
+func runPaymentsHistory(cli *cli, args []string) error {
+	var (
+		flags  = newFlagSet("payments history")
+		limit  = flags.Int("limit", 0, "maximum number of events")
+		offset = flags.Int("offset", 0, "number of events to skip")
+		filter = &payments.EventFilter{}
+	)
+
+	if err := flags.Parse(args); err != nil {
+		return newUsageError("%s", err.Error())
+	}
+
+	if err := expectArgs(flags.Args(), 0, 2); err != nil {
+		return err
+	}
+
+	for i, arg := range flags.Args() {
+		address, err := parseAddress([]string{"token", "target"}[i], arg)
+
+		if err != nil {
+			return err
+		}
+
+		if i == 0 {
+			filter.TokenAddress = address
+		} else {
+			filter.TargetAddress = address
+		}
+	}
+
+	filter.Limit = *limit
+	filter.Offset = *offset
+
+	events, err := cli.client.Payments().ListEvents(cli.ctx, filter)
+
+	if err != nil {
+		return err
+	}
+
+	var (
+		outputs = make([]*eventOutput, 0, len(events))
+		rows    = make([][]string, 0, len(events))
+	)
+
+	for _, event := range events {
+		outputs = append(outputs, newEventOutput(event))
+
+		partner := event.Target
+
+		if event.IsReceived() {
+			partner = event.Initiator
+		}
+
+		rows = append(rows, []string{
+			event.LogTime.Format("2006-01-02T15:04:05Z07:00"),
+			string(event.EventName),
+			event.TokenAddress.Hex(),
+			partner.Hex(),
+			event.Amount.String(),
+			strconv.FormatUint(event.Identifier, 10),
+		})
+	}
+
+	return cli.printer.print(outputs, []string{"TIME", "EVENT", "TOKEN", "PARTNER", "AMOUNT", "IDENTIFIER"}, rows)
+}
+
+func runConnectionsList(cli *cli, args []string) error {
+	if err := expectArgs(args, 0, 0); err != nil {
+		return err
+	}
+
+	connections, err := cli.client.Connections().List(cli.ctx)
+
+	if err != nil {
+		return err
+	}
+
+	var (
+		tokens = make([]common.Address, 0, len(connections))
+		rows   = make([][]string, 0, len(connections))
+	)
+
+	for token := range connections {
+		tokens = append(tokens, token)
+	}
+
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].Hex() < tokens[j].Hex() })
+
+	for _, token := range tokens {
+		connection := connections[token]
+
+		rows = append(rows, []string{
+			token.Hex(),
+			connection.Funds.String(),
+			connection.SumDeposits.String(),
+			strconv.FormatInt(connection.Channels, 10),
+		})
+	}
+
+	return cli.printer.print(connections, []string{"TOKEN", "FUNDS", "DEPOSITS", "CHANNELS"}, rows)
+}
+
+func runConnectionsJoin(cli *cli, args []string) error {
+	addresses, err := parseAddresses(args, "token", "")
+
+	if err != nil {
+		return err
+	}
+
+	funds, err := parseAmount("funds", args[1])
+
+	if err != nil {
+		return err
+	}
+
+	if err = cli.client.Connections().Join(cli.ctx, addresses[0], funds); err != nil {
+		return err
+	}
+
+	return cli.printer.print(
+		map[string]interface{}{"token_address": addresses[0], "funds": funds},
+		[]string{"TOKEN", "FUNDS"},
+		[][]string{{addresses[0].Hex(), funds.String()}},
+	)
+}
+
+func runConnectionsLeave(cli *cli, args []string) error {
+	addresses, err := parseAddresses(args, "token")
+
+	if err != nil {
+		return err
+	}
+
+	partners, err := cli.client.Connections().Leave(cli.ctx, addresses[0])
+
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(partners))
+
+	for _, partner := range partners {
+		rows = append(rows, []string{partner.Hex()})
+	}
+
+	return cli.printer.print(partners, []string{"CLOSED PARTNER"}, rows)
+}
+
+func runPendingList(cli *cli, args []string) error {
+	var (
+		err       error
+		addresses []common.Address
+		transfers []*pendingtransfers.Transfer
+	)
+
+	if err = expectArgs(args, 0, 2); err != nil {
+		return err
+	}
+
+	for i, arg := range args {
+		address, err := parseAddress([]string{"token", "partner"}[i], arg)
+
+		if err != nil {
+			return err
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	switch len(addresses) {
+	case 0:
+		transfers, err = cli.client.PendingTransfers().ListAll(cli.ctx)
+	case 1:
+		transfers, err = cli.client.PendingTransfers().ListToken(cli.ctx, addresses[0])
+	default:
+		transfers, err = cli.client.PendingTransfers().ListChannel(cli.ctx, addresses[0], addresses[1])
+	}
+
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(transfers))
+
+	for _, transfer := range transfers {
+		rows = append(rows, []string{
+			transfer.TokenAddress.Hex(),
+			strconv.FormatInt(transfer.ChannelIdentifier, 10),
+			transfer.Role,
+			transfer.Initiator.Hex(),
+			transfer.Target.Hex(),
+			transfer.LockedAmount.String(),
+			strconv.FormatUint(transfer.PaymentIdentifier, 10),
+		})
+	}
+
+	return cli.printer.print(transfers, []string{"TOKEN", "CHANNEL", "ROLE", "INITIATOR", "TARGET", "LOCKED", "IDENTIFIER"}, rows)
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+
+	return flags
+}
+
+func expectArgs(args []string, min, max int) error {
+	if len(args) < min || len(args) > max {
+		return newUsageError("expected between %d and %d arguments, got %d", min, max, len(args))
+	}
+
+	return nil
+}
+
+// parseAddresses expects exactly one argument per name and parses the arguments
+// with a non-empty name as addresses. Arguments with an empty name are left to
+// the caller.
+func parseAddresses(args []string, names ...string) ([]common.Address, error) {
+	if len(args) != len(names) {
+		return nil, newUsageError("expected %d arguments, got %d", len(names), len(args))
+	}
+
+	addresses := make([]common.Address, 0, len(names))
+
+	for i, name := range names {
+		if name == "" {
+			continue
+		}
+
+		address, err := parseAddress(name, args[i])
+
+		if err != nil {
+			return nil, err
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
+
+func parseAddress(name, value string) (common.Address, error) {
+	if !common.IsHexAddress(value) {
+		return common.Address{}, newUsageError("invalid %s address %q", name, value)
+	}
+
+	return common.HexToAddress(value), nil
+}
+
+func parseAmount(name, value string) (*util.Amount, error) {
+	amount, err := util.ParseAmount(value)
+
+	if err != nil {
+		return nil, newUsageError("invalid %s %q", name, value)
+	}
+
+	return amount, nil
+}
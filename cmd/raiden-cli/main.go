@@ -0,0 +1,185 @@
+// Command raiden-cli is a command-line client for a Raiden node built on the
+// raidenclient package. Subcommands mirror the sub-clients, e.g.
+//
+//	raiden-cli -url http://localhost:5001/api/v1 channels list
+//	raiden-cli -output json payments history 0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359
+//
+// The node is configured with -url, with -config pointing at a YAML, TOML or JSON
+// file, or with the RAIDEN_* environment variables. The exit code reflects the
+// class of error returned by the node, see the exit code constants.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	raidenclient "github.com/cpurta/go-raiden-client"
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+)
+
+// Exit codes of raiden-cli.
+const (
+	exitOK              = 0
+	exitError           = 1
+	exitUsage           = 2
+	exitBadRequest      = 3
+	exitPaymentRequired = 4
+	exitNotFound        = 5
+	exitConflict        = 6
+	exitServerError     = 7
+	exitUnreachable     = 8
+)
+
+const defaultURL = "http://localhost:5001/api/v1"
+
+// usageError is returned for invalid command lines and exits with exitUsage.
+type usageError struct {
+	message string
+}
+
+func (err *usageError) Error() string {
+	return err.message
+}
+
+func newUsageError(format string, args ...interface{}) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+type cli struct {
+	client  *raidenclient.Client
+	ctx     context.Context
+	printer *printer
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line and returns the exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	var (
+		err       error
+		cfg       *config.Config
+		flags     = flag.NewFlagSet("raiden-cli", flag.ContinueOnError)
+		rawURL    = flags.String("url", "", "Raiden API URL, e.g. "+defaultURL)
+		file      = flags.String("config", "", "YAML, TOML or JSON config file")
+		output    = flags.String("output", "table", "output format: table or json")
+		timeout   = flags.Duration("timeout", 30*time.Second, "timeout of the whole command")
+		arguments []string
+	)
+
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: raiden-cli [flags] <command> [arguments]\n\ncommands:\n")
+
+		for _, command := range commands {
+			fmt.Fprintf(stderr, "  %s\n", command.usage)
+		}
+
+		fmt.Fprintf(stderr, "\nflags:\n")
+		flags.PrintDefaults()
+	}
+
+	if err = flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if arguments = flags.Args(); len(arguments) == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	if *output != "table" && *output != "json" {
+		fmt.Fprintf(stderr, "invalid output format %q\n", *output)
+		return exitUsage
+	}
+
+	if cfg, err = loadConfig(*rawURL, *file); err != nil {
+		fmt.Fprintln(stderr, "invalid configuration:", err.Error())
+		return exitUsage
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	cli := &cli{
+		client:  raidenclient.NewClient(cfg, http.DefaultClient),
+		ctx:     ctx,
+		printer: &printer{writer: stdout, json: *output == "json"},
+	}
+
+	if err = cli.dispatch(arguments); err != nil {
+		fmt.Fprintln(stderr, "error:", err.Error())
+
+		if _, ok := err.(*usageError); ok {
+			flags.Usage()
+		}
+
+		return exitCode(err)
+	}
+
+	return exitOK
+}
+
+// loadConfig builds the config from the URL flag, the config file flag or the
+// environment, in that order, falling back to a node on localhost.
+func loadConfig(rawURL, file string) (*config.Config, error) {
+	switch {
+	case rawURL != "":
+		return config.Parse(rawURL)
+	case file != "":
+		return config.FromFile(file)
+	case os.Getenv(config.EnvHost) != "":
+		return config.FromEnv()
+	default:
+		return config.Parse(defaultURL)
+	}
+}
+
+// exitCode maps an error to the exit code of its class.
+func exitCode(err error) int {
+	if _, ok := err.(*usageError); ok {
+		return exitUsage
+	}
+
+	apiError, ok := util.AsAPIError(err)
+
+	if !ok {
+		err = util.Cause(err)
+
+		if err == context.DeadlineExceeded || err == context.Canceled {
+			return exitUnreachable
+		}
+
+		if _, ok := err.(net.Error); ok {
+			return exitUnreachable
+		}
+
+		if _, ok := err.(*url.Error); ok {
+			return exitUnreachable
+		}
+
+		return exitError
+	}
+
+	switch {
+	case apiError.StatusCode == http.StatusPaymentRequired:
+		return exitPaymentRequired
+	case apiError.StatusCode == http.StatusNotFound:
+		return exitNotFound
+	case apiError.StatusCode == http.StatusConflict:
+		return exitConflict
+	case apiError.StatusCode >= http.StatusInternalServerError:
+		return exitServerError
+	default:
+		return exitBadRequest
+	}
+}
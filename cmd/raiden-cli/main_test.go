@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/raidentest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	var (
+		ourAddress     = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+		tokenAddress   = "0x2a65Aca4D5fC5B5C859090a6c34d164135398226"
+		partnerAddress = "0x61C808D82A3Ac53231750daDc13c777b59310bD9"
+		node           = raidentest.NewNode(ourAddress)
+		url            = node.Server.URL + "/api/v1"
+	)
+
+	defer node.Close()
+
+	type testcase struct {
+		name             string
+		args             []string
+		expectedCode     int
+		expectedContains []string
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:             "address",
+			args:             []string{"address"},
+			expectedCode:     exitOK,
+			expectedContains: []string{"ADDRESS", ourAddress.Hex()},
+		},
+		testcase{
+			name:         "open channel in unregistered token network",
+			args:         []string{"channels", "open", tokenAddress, partnerAddress, "100"},
+			expectedCode: exitConflict,
+		},
+		testcase{
+			name:             "register token",
+			args:             []string{"tokens", "register", tokenAddress},
+			expectedCode:     exitOK,
+			expectedContains: []string{"TOKEN", "NETWORK", tokenAddress},
+		},
+		testcase{
+			name:             "open channel",
+			args:             []string{"channels", "open", "-settle-timeout", "600", tokenAddress, partnerAddress, "100"},
+			expectedCode:     exitOK,
+			expectedContains: []string{"opened", partnerAddress},
+		},
+		testcase{
+			name:             "open channel with the node default settle timeout",
+			args:             []string{"channels", "open", tokenAddress, ourAddress.Hex(), "100"},
+			expectedCode:     exitOK,
+			expectedContains: []string{"opened", ourAddress.Hex()},
+		},
+		testcase{
+			name:             "deposit",
+			args:             []string{"channels", "deposit", tokenAddress, partnerAddress, "150"},
+			expectedCode:     exitOK,
+			expectedContains: []string{"150"},
+		},
+		testcase{
+			name:         "payment exceeding the balance",
+			args:         []string{"payments", "send", tokenAddress, partnerAddress, "1000"},
+			expectedCode: exitPaymentRequired,
+		},
+		testcase{
+			name:             "payment",
+			args:             []string{"payments", "send", "-key", "order-1", tokenAddress, partnerAddress, "50"},
+			expectedCode:     exitOK,
+			expectedContains: []string{"IDENTIFIER", "50"},
+		},
+		testcase{
+			name:             "payment history",
+			args:             []string{"payments", "history", tokenAddress},
+			expectedCode:     exitOK,
+			expectedContains: []string{"EventPaymentSentSuccess", partnerAddress},
+		},
+		testcase{
+			name:             "list channels",
+			args:             []string{"channels", "list"},
+			expectedCode:     exitOK,
+			expectedContains: []string{"BALANCE", "100"},
+		},
+		testcase{
+			name:         "unknown token network",
+			args:         []string{"tokens", "get", partnerAddress},
+			expectedCode: exitNotFound,
+		},
+		testcase{
+			name:         "invalid address",
+			args:         []string{"tokens", "get", "0x1234"},
+			expectedCode: exitUsage,
+		},
+		testcase{
+			name:         "unknown command",
+			args:         []string{"channels", "settle"},
+			expectedCode: exitUsage,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				stdout = &bytes.Buffer{}
+				stderr = &bytes.Buffer{}
+			)
+
+			code := run(append([]string{"-url", url}, tc.args...), stdout, stderr)
+
+			assert.Equal(t, tc.expectedCode, code, stderr.String())
+
+			for _, expected := range tc.expectedContains {
+				assert.Contains(t, stdout.String(), expected)
+			}
+		})
+	}
+}
+
+func TestRunJSONOutput(t *testing.T) {
+	var (
+		stdout     = &bytes.Buffer{}
+		stderr     = &bytes.Buffer{}
+		ourAddress = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+		node       = raidentest.NewNode(ourAddress)
+		output     = map[string]common.Address{}
+	)
+
+	defer node.Close()
+
+	code := run([]string{"-url", node.Server.URL, "-output", "json", "address"}, stdout, stderr)
+
+	require.Equal(t, exitOK, code, stderr.String())
+	require.NoError(t, json.NewDecoder(strings.NewReader(stdout.String())).Decode(&output))
+	assert.Equal(t, ourAddress, output["address"])
+
+	tokenAddress := common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226")
+	node.RegisterToken(tokenAddress)
+
+	stdout.Reset()
+	code = run([]string{"-url", node.Server.URL, "-output", "json", "channels", "open", tokenAddress.Hex(), "0x61C808D82A3Ac53231750daDc13c777b59310bD9", "100"}, stdout, stderr)
+	require.Equal(t, exitOK, code, stderr.String())
+
+	stdout.Reset()
+	code = run([]string{"-url", node.Server.URL, "-output", "json", "channels", "list"}, stdout, stderr)
+	require.Equal(t, exitOK, code, stderr.String())
+
+	channels := []map[string]interface{}{}
+	require.NoError(t, json.NewDecoder(strings.NewReader(stdout.String())).Decode(&channels))
+	require.Len(t, channels, 1)
+	assert.Equal(t, strings.ToLower("0x61C808D82A3Ac53231750daDc13c777b59310bD9"), channels[0]["partner_address"])
+	assert.Equal(t, "opened", channels[0]["state"])
+	assert.Equal(t, "100", fmt.Sprint(channels[0]["total_deposit"]))
+}
+
+func TestRunUnreachable(t *testing.T) {
+	var (
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+	)
+
+	code := run([]string{"-url", "http://127.0.0.1:1/api/v1", "address"}, stdout, stderr)
+
+	assert.Equal(t, exitUnreachable, code, stderr.String())
+}
+
+func TestRunIdempotentPaymentErrors(t *testing.T) {
+	var (
+		stdout         = &bytes.Buffer{}
+		stderr         = &bytes.Buffer{}
+		tokenAddress   = "0x2a65Aca4D5fC5B5C859090a6c34d164135398226"
+		partnerAddress = "0x61C808D82A3Ac53231750daDc13c777b59310bD9"
+	)
+
+	// errors while reconciling an idempotent payment keep their class
+	code := run([]string{"-url", "http://127.0.0.1:1/api/v1", "payments", "send", "-key", "order-1", tokenAddress, partnerAddress, "50"}, stdout, stderr)
+
+	assert.Equal(t, exitUnreachable, code, stderr.String())
+	assert.Contains(t, stderr.String(), "unable to reconcile payment")
+}
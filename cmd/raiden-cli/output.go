@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cpurta/go-raiden-client/channels"
+	"github.com/cpurta/go-raiden-client/payments"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// channelOutput is the JSON output of a channel, which has no JSON field names of
+// its own.
+type channelOutput struct {
+	TokenNetworkAddress common.Address `json:"token_network_address"`
+	ChannelIdentifier   int64          `json:"channel_identifier"`
+	PartnerAddress      common.Address `json:"partner_address"`
+	TokenAddress        common.Address `json:"token_address"`
+	Balance             *util.Amount   `json:"balance"`
+	TotalDeposit        *util.Amount   `json:"total_deposit"`
+	TotalWithdraw       *util.Amount   `json:"total_withdraw"`
+	State               string         `json:"state"`
+	SettleTimeout       int64          `json:"settle_timeout"`
+	RevealTimeout       int64          `json:"reveal_timeout"`
+}
+
+// eventOutput is the JSON output of a payment event, which has no JSON field
+// names of its own.
+type eventOutput struct {
+	Event        payments.EventType `json:"event"`
+	Amount       *util.Amount       `json:"amount,omitempty"`
+	Initiator    *common.Address    `json:"initiator,omitempty"`
+	Target       *common.Address    `json:"target,omitempty"`
+	TokenAddress common.Address     `json:"token_address"`
+	Identifier   uint64             `json:"identifier"`
+	Reason       string             `json:"reason,omitempty"`
+	LogTime      time.Time          `json:"log_time"`
+}
+
+func newChannelOutput(channel *channels.Channel) *channelOutput {
+	return &channelOutput{
+		TokenNetworkAddress: channel.TokenNetworkIdentifier,
+		ChannelIdentifier:   channel.ChannelIdentifier,
+		PartnerAddress:      channel.PartnerAddress,
+		TokenAddress:        channel.TokenAddress,
+		Balance:             channel.Balance,
+		TotalDeposit:        channel.TotalDeposit,
+		TotalWithdraw:       channel.TotalWithdraw,
+		State:               channel.State.String(),
+		SettleTimeout:       channel.SettleTimeout,
+		RevealTimeout:       channel.RevealTimeout,
+	}
+}
+
+func newEventOutput(event *payments.Event) *eventOutput {
+	output := &eventOutput{
+		Event:        event.EventName,
+		Amount:       event.Amount,
+		TokenAddress: event.TokenAddress,
+		Identifier:   event.Identifier,
+		Reason:       event.Reason,
+		LogTime:      event.LogTime,
+	}
+
+	if event.IsReceived() {
+		output.Initiator = &event.Initiator
+	} else {
+		output.Target = &event.Target
+	}
+
+	return output
+}
+
+// printer writes command results either as an aligned table or as indented JSON.
+type printer struct {
+	writer io.Writer
+	json   bool
+}
+
+// print writes the value as JSON or the header and rows as a table.
+func (printer *printer) print(value interface{}, header []string, rows [][]string) error {
+	if printer.json {
+		encoder := json.NewEncoder(printer.writer)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(value)
+	}
+
+	writer := tabwriter.NewWriter(printer.writer, 0, 4, 2, ' ', 0)
+
+	if len(header) > 0 {
+		fmt.Fprintln(writer, strings.Join(header, "\t"))
+	}
+
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// HMACTimestampHeader is the header holding the unix time a request was
+	// signed at by an HMAC authenticator.
+	HMACTimestampHeader = "X-Raiden-Timestamp"
+)
+
+// Authenticator adds credentials to an outgoing request before it is sent to the
+// Raiden node. The encoded request body, which is nil for requests without a
+// body, is passed along so that authenticators are able to sign it.
+type Authenticator interface {
+	Authenticate(request *http.Request, body []byte) error
+}
+
+// AuthenticatorFunc allows a plain function to be used as an Authenticator, e.g.
+// to set custom headers that are computed per request.
+type AuthenticatorFunc func(request *http.Request, body []byte) error
+
+// Authenticate calls the function itself.
+func (authenticate AuthenticatorFunc) Authenticate(request *http.Request, body []byte) error {
+	return authenticate(request, body)
+}
+
+// BearerToken returns an authenticator that sets a static bearer token in the
+// Authorization header of every request.
+func BearerToken(token string) Authenticator {
+	return AuthenticatorFunc(func(request *http.Request, body []byte) error {
+		request.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// BasicAuth returns an authenticator that sets HTTP basic authentication
+// credentials on every request.
+func BasicAuth(username, password string) Authenticator {
+	return AuthenticatorFunc(func(request *http.Request, body []byte) error {
+		request.SetBasicAuth(username, password)
+		return nil
+	})
+}
+
+// StaticHeaders returns an authenticator that sets the given headers on every
+// request.
+func StaticHeaders(headers map[string]string) Authenticator {
+	return AuthenticatorFunc(func(request *http.Request, body []byte) error {
+		for key, value := range headers {
+			request.Header.Set(key, value)
+		}
+
+		return nil
+	})
+}
+
+// ChainAuthenticators returns an authenticator that applies all of the given
+// authenticators in order and stops at the first one returning an error.
+func ChainAuthenticators(authenticators ...Authenticator) Authenticator {
+	return AuthenticatorFunc(func(request *http.Request, body []byte) error {
+		for _, authenticator := range authenticators {
+			if err := authenticator.Authenticate(request, body); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// HMACAuthenticator signs every request with HMAC-SHA256. The signed message is
+// made up of the request method, the request URI including the query, the unix
+// timestamp set in the HMACTimestampHeader and the hex encoded SHA-256 hash of the
+// body, separated by newlines. The signature is sent in the Authorization header
+// as `HMAC-SHA256 keyId="<KeyID>", signature="<base64 signature>"`.
+type HMACAuthenticator struct {
+	KeyID  string
+	Secret []byte
+
+	// Now returns the time requests are signed at. It defaults to time.Now.
+	Now func() time.Time
+}
+
+// NewHMACAuthenticator returns an authenticator that signs every request with the
+// given key using HMAC-SHA256.
+func NewHMACAuthenticator(keyID string, secret []byte) *HMACAuthenticator {
+	return &HMACAuthenticator{
+		KeyID:  keyID,
+		Secret: secret,
+		Now:    time.Now,
+	}
+}
+
+// Authenticate will sign the request and set the timestamp and Authorization
+// headers.
+func (authenticator *HMACAuthenticator) Authenticate(request *http.Request, body []byte) error {
+	var (
+		now       = time.Now
+		bodyHash  = sha256.Sum256(body)
+		signature = hmac.New(sha256.New, authenticator.Secret)
+	)
+
+	if len(authenticator.Secret) == 0 {
+		return fmt.Errorf("hmac authenticator has no secret")
+	}
+
+	if authenticator.Now != nil {
+		now = authenticator.Now
+	}
+
+	timestamp := strconv.FormatInt(now().Unix(), 10)
+
+	fmt.Fprintf(signature, "%s\n%s\n%s\n%s", request.Method, request.URL.RequestURI(), timestamp, hex.EncodeToString(bodyHash[:]))
+
+	request.Header.Set(HMACTimestampHeader, timestamp)
+	request.Header.Set("Authorization", fmt.Sprintf(`HMAC-SHA256 keyId="%s", signature="%s"`, authenticator.KeyID, base64.StdEncoding.EncodeToString(signature.Sum(nil))))
+
+	return nil
+}
@@ -1,8 +1,69 @@
 package config
 
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultAPIVersion is the API version used when none is configured.
+const DefaultAPIVersion = "v1"
+
+// SupportedAPIVersions are the Raiden API versions this client is able to talk to.
+var SupportedAPIVersions = []string{"v1"}
+
 // Config holds the needed information for a Raiden client to make API requests
 // to a Raiden node.
 type Config struct {
 	Host       string
 	APIVersion string
+
+	// Timeout, if positive, limits the time of every single request attempt.
+	Timeout time.Duration
+
+	// Authenticator, if set, adds credentials to every request made by the
+	// sub-clients, e.g. for nodes running behind an authenticating proxy.
+	Authenticator Authenticator
+
+	// RetryPolicy, if set, retries idempotent requests that failed because of a
+	// transient error. Requests are not retried if it is nil.
+	RetryPolicy *RetryPolicy
+}
+
+// Validate returns an error if the host is not an absolute http or https URL or
+// the API version is not supported.
+func (config *Config) Validate() error {
+	var (
+		err     error
+		hostURL *url.URL
+	)
+
+	if config.Host == "" {
+		return fmt.Errorf("host must not be empty")
+	}
+
+	if hostURL, err = url.Parse(config.Host); err != nil {
+		return fmt.Errorf("invalid host %q: %s", config.Host, err.Error())
+	}
+
+	if hostURL.Scheme != "http" && hostURL.Scheme != "https" {
+		return fmt.Errorf("invalid host %q: scheme must be http or https", config.Host)
+	}
+
+	if hostURL.Host == "" {
+		return fmt.Errorf("invalid host %q: missing host name", config.Host)
+	}
+
+	if hostURL.RawQuery != "" || hostURL.Fragment != "" || strings.HasSuffix(hostURL.Path, "/") {
+		return fmt.Errorf("invalid host %q: must not have a query, fragment or trailing slash", config.Host)
+	}
+
+	for _, version := range SupportedAPIVersions {
+		if config.APIVersion == version {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported API version %q, supported versions are %s", config.APIVersion, strings.Join(SupportedAPIVersions, ", "))
 }
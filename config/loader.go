@@ -0,0 +1,302 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Environment variables read by FromEnv.
+const (
+	EnvHost              = "RAIDEN_HOST"
+	EnvAPIVersion        = "RAIDEN_API_VERSION"
+	EnvTimeout           = "RAIDEN_TIMEOUT"
+	EnvAuthToken         = "RAIDEN_AUTH_TOKEN"
+	EnvAuthUsername      = "RAIDEN_AUTH_USERNAME"
+	EnvAuthPassword      = "RAIDEN_AUTH_PASSWORD"
+	EnvHMACKeyID         = "RAIDEN_HMAC_KEY_ID"
+	EnvHMACSecret        = "RAIDEN_HMAC_SECRET"
+	EnvRetryMaxAttempts  = "RAIDEN_RETRY_MAX_ATTEMPTS"
+	EnvRetryInitialDelay = "RAIDEN_RETRY_INITIAL_BACKOFF"
+	EnvRetryMaxDelay     = "RAIDEN_RETRY_MAX_BACKOFF"
+)
+
+var apiPathPattern = regexp.MustCompile(`^(.*)/api/(v[0-9]+)/?$`)
+
+// fileConfig is the format of configuration files and environment variables
+// before they are turned into a Config. Durations are strings such as "30s".
+type fileConfig struct {
+	URL        string `json:"url" yaml:"url" toml:"url"`
+	Host       string `json:"host" yaml:"host" toml:"host"`
+	APIVersion string `json:"api_version" yaml:"api_version" toml:"api_version"`
+	Timeout    string `json:"timeout" yaml:"timeout" toml:"timeout"`
+	Auth       struct {
+		Token      string `json:"token" yaml:"token" toml:"token"`
+		Username   string `json:"username" yaml:"username" toml:"username"`
+		Password   string `json:"password" yaml:"password" toml:"password"`
+		HMACKeyID  string `json:"hmac_key_id" yaml:"hmac_key_id" toml:"hmac_key_id"`
+		HMACSecret string `json:"hmac_secret" yaml:"hmac_secret" toml:"hmac_secret"`
+	} `json:"auth" yaml:"auth" toml:"auth"`
+	Retry struct {
+		MaxAttempts    int    `json:"max_attempts" yaml:"max_attempts" toml:"max_attempts"`
+		InitialBackoff string `json:"initial_backoff" yaml:"initial_backoff" toml:"initial_backoff"`
+		MaxBackoff     string `json:"max_backoff" yaml:"max_backoff" toml:"max_backoff"`
+	} `json:"retry" yaml:"retry" toml:"retry"`
+}
+
+// Parse returns a config for the Raiden API at the given URL, e.g.
+// "http://localhost:5001/api/v1". If the URL has no /api/<version> path the
+// default API version is used.
+func Parse(rawURL string) (*Config, error) {
+	var (
+		config = &Config{}
+	)
+
+	if err := config.setURL(rawURL); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// FromEnv returns a config built from the RAIDEN_* environment variables.
+// RAIDEN_HOST may either be a host such as "http://localhost:5001" or a full API
+// URL such as "http://localhost:5001/api/v1".
+func FromEnv() (*Config, error) {
+	var (
+		err  error
+		file = &fileConfig{}
+	)
+
+	file.URL = os.Getenv(EnvHost)
+	file.APIVersion = os.Getenv(EnvAPIVersion)
+	file.Timeout = os.Getenv(EnvTimeout)
+	file.Auth.Token = os.Getenv(EnvAuthToken)
+	file.Auth.Username = os.Getenv(EnvAuthUsername)
+	file.Auth.Password = os.Getenv(EnvAuthPassword)
+	file.Auth.HMACKeyID = os.Getenv(EnvHMACKeyID)
+	file.Auth.HMACSecret = os.Getenv(EnvHMACSecret)
+	file.Retry.InitialBackoff = os.Getenv(EnvRetryInitialDelay)
+	file.Retry.MaxBackoff = os.Getenv(EnvRetryMaxDelay)
+
+	if value := os.Getenv(EnvRetryMaxAttempts); value != "" {
+		if file.Retry.MaxAttempts, err = strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %s", EnvRetryMaxAttempts, value, err.Error())
+		}
+	}
+
+	if file.URL == "" {
+		return nil, fmt.Errorf("%s is not set", EnvHost)
+	}
+
+	return file.toConfig()
+}
+
+// FromFile returns a config read from a YAML, TOML or JSON file. The format is
+// chosen by the file extension: .yaml or .yml, .toml and .json. Keys that are not
+// part of the config are rejected in every format.
+func FromFile(path string) (*Config, error) {
+	var (
+		err      error
+		contents []byte
+		file     = &fileConfig{}
+	)
+
+	if contents, err = ioutil.ReadFile(path); err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = decodeYAML(contents, file)
+	case ".json":
+		err = decodeJSON(contents, file)
+	case ".toml":
+		err = decodeTOML(contents, file)
+	default:
+		return nil, fmt.Errorf("unsupported config file format %q", filepath.Ext(path))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %s", path, err.Error())
+	}
+
+	return file.toConfig()
+}
+
+func decodeYAML(contents []byte, file *fileConfig) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(contents))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(file); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+func decodeJSON(contents []byte, file *fileConfig) error {
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(file)
+}
+
+func decodeTOML(contents []byte, file *fileConfig) error {
+	var (
+		keys []string
+	)
+
+	metadata, err := toml.Decode(string(contents), file)
+
+	if err != nil {
+		return err
+	}
+
+	for _, key := range metadata.Undecoded() {
+		keys = append(keys, key.String())
+	}
+
+	if len(keys) > 0 {
+		return fmt.Errorf("unknown keys %s", strings.Join(keys, ", "))
+	}
+
+	return nil
+}
+
+func (file *fileConfig) toConfig() (*Config, error) {
+	var (
+		err    error
+		config = &Config{
+			Host:       file.Host,
+			APIVersion: file.APIVersion,
+		}
+	)
+
+	if file.URL != "" {
+		if err = config.setURL(file.URL); err != nil {
+			return nil, err
+		}
+
+		if file.APIVersion != "" {
+			config.APIVersion = file.APIVersion
+		}
+	}
+
+	if config.APIVersion == "" {
+		config.APIVersion = DefaultAPIVersion
+	}
+
+	if config.Timeout, err = parseDuration("timeout", file.Timeout); err != nil {
+		return nil, err
+	}
+
+	if config.Authenticator, err = file.authenticator(); err != nil {
+		return nil, err
+	}
+
+	if file.Retry.MaxAttempts > 0 {
+		config.RetryPolicy = DefaultRetryPolicy()
+		config.RetryPolicy.MaxAttempts = file.Retry.MaxAttempts
+
+		if file.Retry.InitialBackoff != "" {
+			if config.RetryPolicy.InitialBackoff, err = parseDuration("retry initial backoff", file.Retry.InitialBackoff); err != nil {
+				return nil, err
+			}
+		}
+
+		if file.Retry.MaxBackoff != "" {
+			if config.RetryPolicy.MaxBackoff, err = parseDuration("retry max backoff", file.Retry.MaxBackoff); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err = config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (file *fileConfig) authenticator() (Authenticator, error) {
+	var (
+		authenticators []Authenticator
+	)
+
+	if file.Auth.Token != "" {
+		authenticators = append(authenticators, BearerToken(file.Auth.Token))
+	}
+
+	if file.Auth.Username != "" {
+		authenticators = append(authenticators, BasicAuth(file.Auth.Username, file.Auth.Password))
+	}
+
+	if file.Auth.HMACSecret != "" {
+		authenticators = append(authenticators, NewHMACAuthenticator(file.Auth.HMACKeyID, []byte(file.Auth.HMACSecret)))
+	}
+
+	switch len(authenticators) {
+	case 0:
+		return nil, nil
+	case 1:
+		return authenticators[0], nil
+	default:
+		return nil, fmt.Errorf("only one of token, username or hmac secret may be configured")
+	}
+}
+
+// setURL sets the host and API version from a URL that may end in an
+// /api/<version> path.
+func (config *Config) setURL(rawURL string) error {
+	var (
+		err       error
+		parsedURL *url.URL
+	)
+
+	if parsedURL, err = url.Parse(strings.TrimSpace(rawURL)); err != nil {
+		return fmt.Errorf("invalid url %q: %s", rawURL, err.Error())
+	}
+
+	config.APIVersion = DefaultAPIVersion
+
+	if matches := apiPathPattern.FindStringSubmatch(parsedURL.Path); matches != nil {
+		parsedURL.Path = matches[1]
+		config.APIVersion = matches[2]
+	}
+
+	parsedURL.Path = strings.TrimSuffix(parsedURL.Path, "/")
+	config.Host = parsedURL.String()
+
+	return nil
+}
+
+func parseDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %s", name, value, err.Error())
+	}
+
+	return duration, nil
+}
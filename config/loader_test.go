@@ -0,0 +1,276 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleParse() {
+	config, err := Parse("http://localhost:5001/api/v1")
+
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(config.Host, config.APIVersion)
+	// Output: http://localhost:5001 v1
+}
+
+func TestParse(t *testing.T) {
+	type testcase struct {
+		name           string
+		url            string
+		expectedConfig *Config
+		expectedError  error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:           "api url",
+			url:            "http://node:5001/api/v1",
+			expectedConfig: &Config{Host: "http://node:5001", APIVersion: "v1"},
+		},
+		testcase{
+			name:           "host without api path",
+			url:            "http://node:5001/",
+			expectedConfig: &Config{Host: "http://node:5001", APIVersion: "v1"},
+		},
+		testcase{
+			name:           "api behind a proxy path",
+			url:            "https://proxy.example.com/raiden/api/v1/",
+			expectedConfig: &Config{Host: "https://proxy.example.com/raiden", APIVersion: "v1"},
+		},
+		testcase{
+			name:          "unsupported api version",
+			url:           "http://node:5001/api/v2",
+			expectedError: errors.New(`unsupported API version "v2", supported versions are v1`),
+		},
+		testcase{
+			name:          "missing scheme",
+			url:           "node:5001",
+			expectedError: errors.New(`invalid host "node:5001": scheme must be http or https`),
+		},
+		testcase{
+			name:          "missing host name",
+			url:           "http:///api/v1",
+			expectedError: errors.New(`invalid host "http:": missing host name`),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := Parse(tc.url)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, config)
+		})
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	type testcase struct {
+		name          string
+		env           map[string]string
+		check         func(t *testing.T, config *Config)
+		expectedError error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "host, timeout, token and retries",
+			env: map[string]string{
+				EnvHost:             "http://node:5001",
+				EnvTimeout:          "30s",
+				EnvAuthToken:        "abc",
+				EnvRetryMaxAttempts: "5",
+			},
+			check: func(t *testing.T, config *Config) {
+				assert.Equal(t, "http://node:5001", config.Host)
+				assert.Equal(t, "v1", config.APIVersion)
+				assert.Equal(t, 30*time.Second, config.Timeout)
+				assert.NotNil(t, config.Authenticator)
+				require.NotNil(t, config.RetryPolicy)
+				assert.Equal(t, 5, config.RetryPolicy.MaxAttempts)
+			},
+		},
+		testcase{
+			name:          "missing host",
+			env:           map[string]string{},
+			expectedError: errors.New("RAIDEN_HOST is not set"),
+		},
+		testcase{
+			name: "invalid timeout",
+			env: map[string]string{
+				EnvHost:    "http://node:5001",
+				EnvTimeout: "soon",
+			},
+			expectedError: errors.New(`invalid timeout "soon": time: invalid duration "soon"`),
+		},
+		testcase{
+			name: "conflicting authentication",
+			env: map[string]string{
+				EnvHost:         "http://node:5001",
+				EnvAuthToken:    "abc",
+				EnvAuthUsername: "raiden",
+			},
+			expectedError: errors.New("only one of token, username or hmac secret may be configured"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{EnvHost, EnvAPIVersion, EnvTimeout, EnvAuthToken, EnvAuthUsername, EnvAuthPassword, EnvHMACKeyID, EnvHMACSecret, EnvRetryMaxAttempts, EnvRetryInitialDelay, EnvRetryMaxDelay} {
+				os.Unsetenv(key)
+			}
+
+			for key, value := range tc.env {
+				os.Setenv(key, value)
+				defer os.Unsetenv(key)
+			}
+
+			config, err := FromEnv()
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			tc.check(t, config)
+		})
+	}
+}
+
+func TestFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "raiden-config")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	type testcase struct {
+		name          string
+		file          string
+		contents      string
+		expectedError bool
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "yaml",
+			file: "raiden.yaml",
+			contents: `
+url: http://node:5001/api/v1
+timeout: 10s
+auth:
+  token: abc
+retry:
+  max_attempts: 4
+  initial_backoff: 100ms
+`,
+		},
+		testcase{
+			name: "toml",
+			file: "raiden.toml",
+			contents: `
+# raiden node behind the proxy
+url = "http://node:5001/api/v1"
+timeout = "10s" # per request
+
+[auth]
+token = 'abc'
+
+[retry]
+max_attempts = 4
+initial_backoff = "100ms"
+`,
+		},
+		testcase{
+			name:     "json",
+			file:     "raiden.json",
+			contents: `{"host":"http://node:5001","api_version":"v1","timeout":"10s","auth":{"token":"abc"},"retry":{"max_attempts":4,"initial_backoff":"100ms"}}`,
+		},
+		testcase{
+			name:          "unknown extension",
+			file:          "raiden.ini",
+			contents:      `host=http://node:5001`,
+			expectedError: true,
+		},
+		testcase{
+			name:          "malformed toml",
+			file:          "broken.toml",
+			contents:      `url "http://node:5001"`,
+			expectedError: true,
+		},
+		testcase{
+			name: "toml with dotted keys and inline tables",
+			file: "dotted.toml",
+			contents: `
+url = "http://node:5001/api/v1"
+timeout = "10s"
+auth = { token = "abc" }
+retry.max_attempts = 4
+retry.initial_backoff = "100ms"
+`,
+		},
+		testcase{
+			name:          "unknown yaml key",
+			file:          "unknown.yaml",
+			contents:      "url: http://node:5001/api/v1\nretry:\n  max_attempt: 4\n",
+			expectedError: true,
+		},
+		testcase{
+			name:          "unknown toml key",
+			file:          "unknown.toml",
+			contents:      "url = \"http://node:5001/api/v1\"\n[retry]\nmax_attempt = 4\n",
+			expectedError: true,
+		},
+		testcase{
+			name:          "unknown json key",
+			file:          "unknown.json",
+			contents:      `{"url":"http://node:5001/api/v1","retry":{"max_attempt":4}}`,
+			expectedError: true,
+		},
+		testcase{
+			name:          "invalid host",
+			file:          "invalid.yaml",
+			contents:      `host: node:5001`,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, tc.file)
+			require.NoError(t, ioutil.WriteFile(path, []byte(tc.contents), 0600))
+
+			config, err := FromFile(path)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "http://node:5001", config.Host)
+			assert.Equal(t, "v1", config.APIVersion)
+			assert.Equal(t, 10*time.Second, config.Timeout)
+			assert.NotNil(t, config.Authenticator)
+			require.NotNil(t, config.RetryPolicy)
+			assert.Equal(t, 4, config.RetryPolicy.MaxAttempts)
+			assert.Equal(t, 100*time.Millisecond, config.RetryPolicy.InitialBackoff)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures how requests that failed because of a transient error,
+// e.g. a connection reset or a 503 while the node is syncing, are retried. Only
+// idempotent requests are retried: GET and HEAD requests and requests whose
+// context has been marked as idempotent by the sub-client making them.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one.
+	MaxAttempts int
+	// InitialBackoff is the time waited before the first retry. It doubles with
+	// every further retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum time waited between two attempts. The backoff is
+	// not capped if it is 0.
+	MaxBackoff time.Duration
+	// Jitter is the fraction, between 0 and 1, by which a backoff is randomly
+	// shortened to spread out retries of concurrent clients.
+	Jitter float64
+	// RetryableStatusCodes are the response status codes a request is retried on.
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns a policy making up to 3 attempts with a backoff
+// starting at 250ms that retries on 502, 503 and 504 responses.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 250 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Jitter:         0.2,
+		RetryableStatusCodes: []int{
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// Backoff returns the time to wait before the given retry, starting at 1 for the
+// first retry.
+func (policy *RetryPolicy) Backoff(retry int) time.Duration {
+	var (
+		backoff = policy.InitialBackoff
+	)
+
+	for i := 1; i < retry && backoff < math.MaxInt64/2; i++ {
+		backoff *= 2
+
+		if policy.MaxBackoff > 0 && backoff >= policy.MaxBackoff {
+			break
+		}
+	}
+
+	if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+		backoff = policy.MaxBackoff
+	}
+
+	if policy.Jitter > 0 {
+		backoff -= time.Duration(rand.Float64() * policy.Jitter * float64(backoff))
+	}
+
+	return backoff
+}
+
+// IsRetryableStatus returns whether a response with the given status code should
+// be retried.
+func (policy *RetryPolicy) IsRetryableStatus(statusCode int) bool {
+	for _, retryable := range policy.RetryableStatusCodes {
+		if statusCode == retryable {
+			return true
+		}
+	}
+
+	return false
+}
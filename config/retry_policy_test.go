@@ -0,0 +1,50 @@
+package config
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	var (
+		policy = &RetryPolicy{
+			InitialBackoff: 100 * time.Millisecond,
+			MaxBackoff:     time.Second,
+		}
+	)
+
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, 400*time.Millisecond, policy.Backoff(3))
+	assert.Equal(t, time.Second, policy.Backoff(5))
+	assert.Equal(t, time.Second, policy.Backoff(64))
+
+	// without a maximum the backoff keeps doubling
+	policy.MaxBackoff = 0
+
+	assert.Equal(t, 200*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, 1600*time.Millisecond, policy.Backoff(5))
+	assert.True(t, policy.Backoff(64) > 0)
+
+	policy.Jitter = 0.5
+
+	for i := 0; i < 100; i++ {
+		backoff := policy.Backoff(1)
+
+		assert.True(t, backoff > 50*time.Millisecond && backoff <= 100*time.Millisecond, "backoff %s out of range", backoff)
+	}
+}
+
+func TestRetryPolicyIsRetryableStatus(t *testing.T) {
+	var (
+		policy = DefaultRetryPolicy()
+	)
+
+	assert.True(t, policy.IsRetryableStatus(http.StatusServiceUnavailable))
+	assert.True(t, policy.IsRetryableStatus(http.StatusBadGateway))
+	assert.False(t, policy.IsRetryableStatus(http.StatusConflict))
+	assert.False(t, policy.IsRetryableStatus(http.StatusPaymentRequired))
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+)
+
+// NewTLSConfig loads a client certificate and key from the given PEM files for
+// mutual TLS. If caFile is not empty the certificates in it are used to verify
+// the server instead of the system roots.
+func NewTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	var (
+		err         error
+		certificate tls.Certificate
+		tlsConfig   = &tls.Config{}
+	)
+
+	if certificate, err = tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+		return nil, err
+	}
+
+	tlsConfig.Certificates = []tls.Certificate{certificate}
+
+	if caFile == "" {
+		return tlsConfig, nil
+	}
+
+	var caPEM []byte
+
+	if caPEM, err = ioutil.ReadFile(caFile); err != nil {
+		return nil, err
+	}
+
+	tlsConfig.RootCAs = x509.NewCertPool()
+
+	if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+
+	return tlsConfig, nil
+}
+
+// NewTLSHTTPClient returns an HTTP client that uses the given TLS configuration
+// and otherwise behaves like http.DefaultClient.
+func NewTLSHTTPClient(tlsConfig *tls.Config) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+			TLSClientConfig:       tlsConfig,
+		},
+	}
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCertificate writes a self-signed certificate and its key as PEM files
+// into dir and returns their paths.
+func writeTestCertificate(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "raiden-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	encodedKey, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	var (
+		certFile = filepath.Join(dir, "client.crt")
+		keyFile  = filepath.Join(dir, "client.key")
+	)
+
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: encodedKey}), 0600))
+
+	return certFile, keyFile
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "raiden-tls")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	var (
+		certFile, keyFile = writeTestCertificate(t, dir)
+		emptyFile         = filepath.Join(dir, "empty.pem")
+	)
+
+	require.NoError(t, ioutil.WriteFile(emptyFile, []byte{}, 0600))
+
+	type testcase struct {
+		name          string
+		certFile      string
+		keyFile       string
+		caFile        string
+		expectRootCAs bool
+		expectError   bool
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:     "client certificate only",
+			certFile: certFile,
+			keyFile:  keyFile,
+		},
+		testcase{
+			name:          "client certificate and ca",
+			certFile:      certFile,
+			keyFile:       keyFile,
+			caFile:        certFile,
+			expectRootCAs: true,
+		},
+		testcase{
+			name:        "missing key",
+			certFile:    certFile,
+			keyFile:     filepath.Join(dir, "missing.key"),
+			expectError: true,
+		},
+		testcase{
+			name:        "ca without certificates",
+			certFile:    certFile,
+			keyFile:     keyFile,
+			caFile:      emptyFile,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tlsConfig, err := NewTLSConfig(tc.certFile, tc.keyFile, tc.caFile)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, tlsConfig.Certificates, 1)
+			assert.Equal(t, tc.expectRootCAs, tlsConfig.RootCAs != nil)
+			assert.NotNil(t, NewTLSHTTPClient(tlsConfig).Transport)
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package connections
 
+import "github.com/cpurta/go-raiden-client/util"
+
 // Connection represents a high level information about the Funds, Total deposits
 // and numbers of channels for a given network.
 type Connection struct {
-	Funds       int64 `json:"funds"`
-	SumDeposits int64 `json:"sum_deposits"`
-	Channels    int64 `json:"channels"`
+	Funds       *util.Amount `json:"funds"`
+	SumDeposits *util.Amount `json:"sum_deposits"`
+	Channels    int64        `json:"channels"`
 }
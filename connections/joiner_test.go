@@ -2,13 +2,13 @@ package connections
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"testing"
 
 	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -23,7 +23,7 @@ func ExampleJoiner() {
 			APIVersion: "v1",
 		}
 		tokenAddress = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359") // DAI Stablecoin
-		funds        = int64(1000)
+		funds        = util.NewAmount(1000)
 		err          error
 	)
 
@@ -82,7 +82,7 @@ func TestCloser(t *testing.T) {
 					),
 				)
 			},
-			expectedError: errors.New("recieved 500 status code: "),
+			expectedError: &util.APIError{StatusCode: http.StatusInternalServerError, Method: "PUT", URL: "http://localhost:5001/api/v1/connections/0x2a65Aca4D5fC5B5C859090a6c34d164135398226"},
 		},
 		testcase{
 			name: "unable to make http request",
@@ -108,7 +108,7 @@ func TestCloser(t *testing.T) {
 
 			tc.prepHTTPMock()
 
-			err = joiner.Join(ctx, tokenAddress, 1337)
+			err = joiner.Join(ctx, tokenAddress, util.NewAmount(1337))
 
 			if tc.expectedError != nil {
 				assert.EqualError(t, err, tc.expectedError.Error())
@@ -2,10 +2,7 @@ package connections
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
-	"net/url"
 
 	"github.com/cpurta/go-raiden-client/config"
 	"github.com/cpurta/go-raiden-client/util"
@@ -37,29 +34,9 @@ func (leaver *defaultLeaver) Leave(ctx context.Context, tokenAddress common.Addr
 		err            error
 		tokens         = make([]string, 0)
 		tokenAddresses = make([]common.Address, 0)
-
-		requestURL *url.URL
-		request    *http.Request
-		response   *http.Response
 	)
 
-	if requestURL, err = leaver.getRequestURL(tokenAddress); err != nil {
-		return nil, err
-	}
-
-	if request, err = http.NewRequest("DELETE", requestURL.String(), nil); err != nil {
-		return nil, err
-	}
-
-	request = request.WithContext(ctx)
-
-	if response, err = leaver.baseClient.HTTPClient.Do(request); err != nil {
-		return nil, err
-	}
-
-	defer response.Body.Close()
-
-	if err = json.NewDecoder(response.Body).Decode(&tokens); err != nil {
+	if err = leaver.baseClient.Do(ctx, "DELETE", []string{"connections", tokenAddress.Hex()}, nil, &tokens); err != nil {
 		return nil, err
 	}
 
@@ -69,17 +46,3 @@ func (leaver *defaultLeaver) Leave(ctx context.Context, tokenAddress common.Addr
 
 	return tokenAddresses, nil
 }
-
-func (leaver *defaultLeaver) getRequestURL(tokenAddress common.Address) (*url.URL, error) {
-	var (
-		err        error
-		endpoint   = fmt.Sprintf("%s/api/%s/connections/%s", leaver.baseClient.Config.Host, leaver.baseClient.Config.APIVersion, tokenAddress.Hex())
-		requestURL *url.URL
-	)
-
-	if requestURL, err = url.Parse(endpoint); err != nil {
-		return nil, err
-	}
-
-	return requestURL, nil
-}
@@ -2,13 +2,13 @@ package connections
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"testing"
 
 	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -91,7 +91,7 @@ func TestLeaver(t *testing.T) {
 				)
 			},
 			expectedAddresses: []common.Address{},
-			expectedError:     errors.New("EOF"),
+			expectedError:     &util.APIError{StatusCode: http.StatusInternalServerError, Method: "DELETE", URL: "http://localhost:5001/api/v1/connections/0x2a65Aca4D5fC5B5C859090a6c34d164135398226"},
 		},
 		testcase{
 			name: "unable to make http request",
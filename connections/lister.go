@@ -2,10 +2,7 @@ package connections
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
-	"net/url"
 
 	"github.com/cpurta/go-raiden-client/config"
 	"github.com/cpurta/go-raiden-client/util"
@@ -41,29 +38,9 @@ func (lister *defaultLister) List(ctx context.Context) (Connections, error) {
 		err         error
 		channels    = make(map[string]*Connection)
 		connections = make(map[common.Address]*Connection)
-
-		requestURL *url.URL
-		request    *http.Request
-		response   *http.Response
 	)
 
-	if requestURL, err = lister.getRequestURL(); err != nil {
-		return nil, err
-	}
-
-	if request, err = http.NewRequest("GET", requestURL.String(), nil); err != nil {
-		return nil, err
-	}
-
-	request = request.WithContext(ctx)
-
-	if response, err = lister.baseClient.HTTPClient.Do(request); err != nil {
-		return nil, err
-	}
-
-	defer response.Body.Close()
-
-	if err = json.NewDecoder(response.Body).Decode(&channels); err != nil {
+	if err = lister.baseClient.Do(ctx, "GET", []string{"connections"}, nil, &channels); err != nil {
 		return nil, err
 	}
 
@@ -73,17 +50,3 @@ func (lister *defaultLister) List(ctx context.Context) (Connections, error) {
 
 	return connections, nil
 }
-
-func (lister *defaultLister) getRequestURL() (*url.URL, error) {
-	var (
-		err        error
-		endpoint   = fmt.Sprintf("%s/api/%s/connections", lister.baseClient.Config.Host, lister.baseClient.Config.APIVersion)
-		requestURL *url.URL
-	)
-
-	if requestURL, err = url.Parse(endpoint); err != nil {
-		return nil, err
-	}
-
-	return requestURL, nil
-}
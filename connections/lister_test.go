@@ -2,13 +2,13 @@ package connections
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"testing"
 
 	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -72,13 +72,13 @@ func TestLister(t *testing.T) {
 			},
 			expectedConnections: Connections{
 				common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226"): &Connection{
-					Funds:       int64(100),
-					SumDeposits: int64(67),
+					Funds:       util.NewAmount(100),
+					SumDeposits: util.NewAmount(67),
 					Channels:    int64(3),
 				},
 				common.HexToAddress("0x0f114A1E9Db192502E7856309cc899952b3db1ED"): &Connection{
-					Funds:       int64(49),
-					SumDeposits: int64(31),
+					Funds:       util.NewAmount(49),
+					SumDeposits: util.NewAmount(31),
 					Channels:    int64(1),
 				},
 			},
@@ -97,7 +97,7 @@ func TestLister(t *testing.T) {
 				)
 			},
 			expectedConnections: nil,
-			expectedError:       errors.New("EOF"),
+			expectedError:       &util.APIError{StatusCode: http.StatusInternalServerError, Method: "GET", URL: "http://localhost:5001/api/v1/connections"},
 		},
 		testcase{
 			name: "unable to make http request",
@@ -0,0 +1,294 @@
+package raidenclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cpurta/go-raiden-client/channels"
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/node"
+	"github.com/cpurta/go-raiden-client/pending_transfers"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NodeError is the error of a single node in a fleet operation.
+type NodeError struct {
+	Node string
+	Err  error
+}
+
+func (err *NodeError) Error() string {
+	return fmt.Sprintf("%s: %s", err.Node, err.Err.Error())
+}
+
+// FleetError is returned by fleet operations that failed on some of the nodes.
+// The results of the other nodes are returned along with it.
+type FleetError struct {
+	Errors []*NodeError
+}
+
+func (err *FleetError) Error() string {
+	messages := make([]string, 0, len(err.Errors))
+
+	for _, nodeError := range err.Errors {
+		messages = append(messages, nodeError.Error())
+	}
+
+	return fmt.Sprintf("%d node(s) failed: %s", len(err.Errors), strings.Join(messages, "; "))
+}
+
+// NodeChannel is a channel tagged with the name of the node it belongs to.
+type NodeChannel struct {
+	Node string
+	*channels.Channel
+}
+
+// NodeTransfer is a pending transfer tagged with the name of the node it belongs
+// to.
+type NodeTransfer struct {
+	Node string
+	*pendingtransfers.Transfer
+}
+
+// Fleet holds the clients of several named Raiden nodes and runs queries against
+// all of them concurrently. A Fleet is safe for concurrent use.
+type Fleet struct {
+	mutex   sync.RWMutex
+	clients map[string]*Client
+}
+
+// NewFleet creates an empty fleet.
+func NewFleet() *Fleet {
+	return &Fleet{
+		clients: make(map[string]*Client),
+	}
+}
+
+// NewFleetFromConfigs creates a fleet with a client for every named config that
+// all share the given HTTP client.
+func NewFleetFromConfigs(configs map[string]*config.Config, httpClient *http.Client) *Fleet {
+	fleet := NewFleet()
+
+	for name, config := range configs {
+		fleet.clients[name] = NewClient(config, httpClient)
+	}
+
+	return fleet
+}
+
+// Add adds the client of a node under the given name, which must be unique
+// within the fleet.
+func (fleet *Fleet) Add(name string, client *Client) error {
+	fleet.mutex.Lock()
+	defer fleet.mutex.Unlock()
+
+	if name == "" {
+		return fmt.Errorf("node name must not be empty")
+	}
+
+	if _, ok := fleet.clients[name]; ok {
+		return fmt.Errorf("node %q is already part of the fleet", name)
+	}
+
+	fleet.clients[name] = client
+
+	return nil
+}
+
+// Remove removes the node with the given name from the fleet.
+func (fleet *Fleet) Remove(name string) {
+	fleet.mutex.Lock()
+	defer fleet.mutex.Unlock()
+
+	delete(fleet.clients, name)
+}
+
+// Client returns the client of the node with the given name.
+func (fleet *Fleet) Client(name string) (*Client, bool) {
+	fleet.mutex.RLock()
+	defer fleet.mutex.RUnlock()
+
+	client, ok := fleet.clients[name]
+
+	return client, ok
+}
+
+// Names returns the sorted names of all nodes in the fleet.
+func (fleet *Fleet) Names() []string {
+	fleet.mutex.RLock()
+	defer fleet.mutex.RUnlock()
+
+	names := make([]string, 0, len(fleet.clients))
+
+	for name := range fleet.clients {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Each calls fn for every node of the fleet concurrently and waits for all calls
+// to return. If any call fails a *FleetError holding the error of every failed
+// node is returned.
+func (fleet *Fleet) Each(ctx context.Context, fn func(ctx context.Context, name string, client *Client) error) error {
+	var (
+		names     = fleet.Names()
+		errs      = make([]error, len(names))
+		waitGroup sync.WaitGroup
+	)
+
+	for i, name := range names {
+		client, ok := fleet.Client(name)
+
+		if !ok {
+			continue
+		}
+
+		waitGroup.Add(1)
+
+		go func(i int, name string, client *Client) {
+			defer waitGroup.Done()
+
+			errs[i] = fn(ctx, name, client)
+		}(i, name, client)
+	}
+
+	waitGroup.Wait()
+
+	fleetError := &FleetError{}
+
+	for i, err := range errs {
+		if err != nil {
+			fleetError.Errors = append(fleetError.Errors, &NodeError{Node: names[i], Err: err})
+		}
+	}
+
+	if len(fleetError.Errors) > 0 {
+		return fleetError
+	}
+
+	return nil
+}
+
+// Addresses returns the Ethereum address of every node by node name.
+func (fleet *Fleet) Addresses(ctx context.Context) (map[string]common.Address, error) {
+	var (
+		mutex     sync.Mutex
+		addresses = make(map[string]common.Address)
+	)
+
+	err := fleet.Each(ctx, func(ctx context.Context, name string, client *Client) error {
+		address, err := client.Address().Get(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		addresses[name] = address
+
+		return nil
+	})
+
+	return addresses, err
+}
+
+// Statuses returns the sync status of every node by node name.
+func (fleet *Fleet) Statuses(ctx context.Context) (map[string]*node.Status, error) {
+	var (
+		mutex    sync.Mutex
+		statuses = make(map[string]*node.Status)
+	)
+
+	err := fleet.Each(ctx, func(ctx context.Context, name string, client *Client) error {
+		status, err := client.Node().Status(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		statuses[name] = status
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+// Channels returns the channels of all nodes ordered by node name.
+func (fleet *Fleet) Channels(ctx context.Context) ([]*NodeChannel, error) {
+	var (
+		mutex  sync.Mutex
+		byNode = make(map[string][]*channels.Channel)
+	)
+
+	err := fleet.Each(ctx, func(ctx context.Context, name string, client *Client) error {
+		nodeChannels, err := client.Channels().List(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		byNode[name] = nodeChannels
+
+		return nil
+	})
+
+	results := make([]*NodeChannel, 0)
+
+	for _, name := range fleet.Names() {
+		for _, channel := range byNode[name] {
+			results = append(results, &NodeChannel{Node: name, Channel: channel})
+		}
+	}
+
+	return results, err
+}
+
+// PendingTransfers returns the pending transfers of all nodes ordered by node
+// name.
+func (fleet *Fleet) PendingTransfers(ctx context.Context) ([]*NodeTransfer, error) {
+	var (
+		mutex  sync.Mutex
+		byNode = make(map[string][]*pendingtransfers.Transfer)
+	)
+
+	err := fleet.Each(ctx, func(ctx context.Context, name string, client *Client) error {
+		transfers, err := client.PendingTransfers().ListAll(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		byNode[name] = transfers
+
+		return nil
+	})
+
+	results := make([]*NodeTransfer, 0)
+
+	for _, name := range fleet.Names() {
+		for _, transfer := range byNode[name] {
+			results = append(results, &NodeTransfer{Node: name, Transfer: transfer})
+		}
+	}
+
+	return results, err
+}
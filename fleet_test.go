@@ -0,0 +1,96 @@
+package raidenclient
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/raidentest"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleFleet() {
+	var (
+		fleet = NewFleetFromConfigs(map[string]*config.Config{
+			"alice": &config.Config{Host: "http://alice:5001", APIVersion: "v1"},
+			"bob":   &config.Config{Host: "http://bob:5001", APIVersion: "v1"},
+		}, http.DefaultClient)
+	)
+
+	channels, err := fleet.Channels(context.TODO())
+
+	if fleetError, ok := err.(*FleetError); ok {
+		for _, nodeError := range fleetError.Errors {
+			log.Println("unable to list channels of node", nodeError.Node, nodeError.Err)
+		}
+	}
+
+	for _, channel := range channels {
+		log.Println(channel.Node, channel.PartnerAddress.Hex(), channel.Balance)
+	}
+}
+
+func TestFleet(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		tokenAddress   = common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226")
+		aliceAddress   = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+		bobAddress     = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
+		alice          = raidentest.NewNode(aliceAddress)
+		bob            = raidentest.NewNode(bobAddress)
+		fleet          = NewFleet()
+		unreachable, _ = config.Parse("http://127.0.0.1:1/api/v1")
+	)
+
+	defer alice.Close()
+	defer bob.Close()
+
+	require.NoError(t, fleet.Add("bob", NewClient(bob.Config(), http.DefaultClient)))
+	require.NoError(t, fleet.Add("alice", NewClient(alice.Config(), http.DefaultClient)))
+	require.NoError(t, fleet.Add("carol", NewClient(unreachable, http.DefaultClient)))
+	assert.Error(t, fleet.Add("alice", NewClient(alice.Config(), http.DefaultClient)))
+	assert.Equal(t, []string{"alice", "bob", "carol"}, fleet.Names())
+
+	for _, node := range []*raidentest.Node{alice, bob} {
+		node.RegisterToken(tokenAddress)
+	}
+
+	_, err := fleet.clients["alice"].Channels().Open(ctx, tokenAddress, bobAddress, util.NewAmount(100), 0)
+	require.NoError(t, err)
+	_, err = fleet.clients["bob"].Channels().Open(ctx, tokenAddress, aliceAddress, util.NewAmount(50), 0)
+	require.NoError(t, err)
+
+	channels, err := fleet.Channels(ctx)
+
+	require.IsType(t, &FleetError{}, err)
+	require.Len(t, err.(*FleetError).Errors, 1)
+	assert.Equal(t, "carol", err.(*FleetError).Errors[0].Node)
+
+	require.Len(t, channels, 2)
+	assert.Equal(t, "alice", channels[0].Node)
+	assert.Equal(t, bobAddress, channels[0].PartnerAddress)
+	assert.Equal(t, "bob", channels[1].Node)
+	assert.Equal(t, util.NewAmount(50), channels[1].Balance)
+
+	fleet.Remove("carol")
+
+	addresses, err := fleet.Addresses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]common.Address{"alice": aliceAddress, "bob": bobAddress}, addresses)
+
+	require.NoError(t, bob.AddPendingTransfer(tokenAddress, aliceAddress, aliceAddress, bobAddress, util.NewAmount(5), 1, "target"))
+
+	transfers, err := fleet.PendingTransfers(ctx)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, "bob", transfers[0].Node)
+
+	statuses, err := fleet.Statuses(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses["alice"].IsReady())
+}
@@ -0,0 +1,37 @@
+package node
+
+import (
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/config"
+)
+
+var (
+	_ StatusGetter   = &Client{}
+	_ VersionGetter  = &Client{}
+	_ SettingsGetter = &Client{}
+	_ Waiter         = &Client{}
+)
+
+// NewClient creates a new node client that is able to get the status, version and
+// settings of a Raiden node and to wait until the node is ready.
+func NewClient(config *config.Config, httpClient *http.Client) *Client {
+	var (
+		statusGetter = NewStatusGetter(config, httpClient)
+	)
+
+	return &Client{
+		StatusGetter:   statusGetter,
+		VersionGetter:  NewVersionGetter(config, httpClient),
+		SettingsGetter: NewSettingsGetter(config, httpClient),
+		Waiter:         NewWaiter(statusGetter, DefaultReadyPollInterval),
+	}
+}
+
+// Client allows for status, version and settings operations for a Raiden node.
+type Client struct {
+	StatusGetter
+	VersionGetter
+	SettingsGetter
+	Waiter
+}
@@ -0,0 +1,29 @@
+package node
+
+const (
+	// StatusReady is reported by a node that is synced with the blockchain and
+	// ready to accept requests.
+	StatusReady = "ready"
+	// StatusSyncing is reported by a node that is still syncing with the
+	// blockchain.
+	StatusSyncing = "syncing"
+	// StatusUnavailable is reported by a node that is not able to serve requests.
+	StatusUnavailable = "unavailable"
+)
+
+// Status represents the sync status of a Raiden node and, while the node is
+// syncing, the number of blocks it still has to sync.
+type Status struct {
+	Status       string `json:"status"`
+	BlocksToSync int64  `json:"blocks_to_sync"`
+}
+
+// IsReady returns whether the node is ready to accept requests.
+func (status *Status) IsReady() bool {
+	return status != nil && status.Status == StatusReady
+}
+
+// Settings represents the settings a Raiden node has been started with.
+type Settings struct {
+	PathfindingServiceAddress string `json:"pathfinding_service_address"`
+}
@@ -0,0 +1,48 @@
+package node
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+)
+
+// SettingsGetter is a generic interface to get the settings a Raiden node has
+// been started with. It allows for a context to be passed to allow for request
+// timeouts and/or deadlines on the response.
+type SettingsGetter interface {
+	Settings(ctx context.Context) (*Settings, error)
+}
+
+var _ SettingsGetter = &defaultSettingsGetter{}
+
+// NewSettingsGetter will return a default settings getter for a configured Raiden
+// node.
+func NewSettingsGetter(config *config.Config, httpClient *http.Client) SettingsGetter {
+	return &defaultSettingsGetter{
+		baseClient: &util.BaseClient{
+			Config:     config,
+			HTTPClient: httpClient,
+		},
+	}
+}
+
+type defaultSettingsGetter struct {
+	baseClient *util.BaseClient
+}
+
+// Settings will return the settings of the Raiden node such as the address of the
+// pathfinding service it uses.
+func (getter *defaultSettingsGetter) Settings(ctx context.Context) (*Settings, error) {
+	var (
+		err      error
+		settings = &Settings{}
+	)
+
+	if err = getter.baseClient.Do(ctx, "GET", []string{"settings"}, nil, settings); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,125 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleSettingsGetter() {
+	var (
+		nodeClient *Client
+		config     = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		settings *Settings
+		err      error
+	)
+
+	nodeClient = NewClient(config, http.DefaultClient)
+
+	if settings, err = nodeClient.Settings(context.Background()); err != nil {
+		panic(fmt.Sprintf("unable to get raiden node settings: %s", err.Error()))
+	}
+
+	fmt.Println("pathfinding service:", settings.PathfindingServiceAddress)
+}
+
+func TestSettingsGetter(t *testing.T) {
+	var (
+		localhostIP = "[::1]"
+		config      = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+	)
+
+	if os.Getenv("USE_IPV4") != "" {
+		localhostIP = "127.0.0.1"
+	}
+
+	type testcase struct {
+		name             string
+		prepHTTPMock     func()
+		expectedSettings *Settings
+		expectedError    error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "successfully got settings",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/settings",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						`{"pathfinding_service_address":"https://pfs-goerli.services-test.raiden.network"}`,
+					),
+				)
+			},
+			expectedError: nil,
+			expectedSettings: &Settings{
+				PathfindingServiceAddress: "https://pfs-goerli.services-test.raiden.network",
+			},
+		},
+		testcase{
+			name: "unexpected 500 response",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/settings",
+					httpmock.NewStringResponder(
+						http.StatusInternalServerError,
+						``,
+					),
+				)
+			},
+			expectedError:    &util.APIError{StatusCode: http.StatusInternalServerError, Method: "GET", URL: "http://localhost:5001/api/v1/settings"},
+			expectedSettings: nil,
+		},
+		testcase{
+			name: "unable to make http request",
+			prepHTTPMock: func() {
+				httpmock.Deactivate()
+			},
+			expectedError:    fmt.Errorf("Get http://localhost:5001/api/v1/settings: dial tcp %s:5001: connect: connection refused", localhostIP),
+			expectedSettings: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				err            error
+				settings       *Settings
+				settingsGetter = NewSettingsGetter(config, http.DefaultClient)
+				ctx            = context.Background()
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			tc.prepHTTPMock()
+
+			settings, err = settingsGetter.Settings(ctx)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSettings, settings)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+)
+
+// statusResponse uses a json.Number for the blocks to sync as nodes report it
+// either as a number or as a string.
+type statusResponse struct {
+	Status       string      `json:"status"`
+	BlocksToSync json.Number `json:"blocks_to_sync"`
+}
+
+// StatusGetter is a generic interface to get the sync status of a Raiden node. It
+// allows for a context to be passed to allow for request timeouts and/or
+// deadlines on the response.
+type StatusGetter interface {
+	Status(ctx context.Context) (*Status, error)
+}
+
+var _ StatusGetter = &defaultStatusGetter{}
+
+// NewStatusGetter will return a default status getter for a configured Raiden
+// node.
+func NewStatusGetter(config *config.Config, httpClient *http.Client) StatusGetter {
+	return &defaultStatusGetter{
+		baseClient: &util.BaseClient{
+			Config:     config,
+			HTTPClient: httpClient,
+		},
+	}
+}
+
+type defaultStatusGetter struct {
+	baseClient *util.BaseClient
+}
+
+// Status will return whether the Raiden node is ready or still syncing and how
+// many blocks it has left to sync.
+func (getter *defaultStatusGetter) Status(ctx context.Context) (*Status, error) {
+	var (
+		err            error
+		statusResponse = &statusResponse{}
+		status         = &Status{}
+	)
+
+	if err = getter.baseClient.Do(ctx, "GET", []string{"status"}, nil, statusResponse); err != nil {
+		return nil, err
+	}
+
+	status.Status = statusResponse.Status
+
+	if statusResponse.BlocksToSync != "" {
+		if status.BlocksToSync, err = statusResponse.BlocksToSync.Int64(); err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
@@ -0,0 +1,143 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleStatusGetter() {
+	var (
+		nodeClient *Client
+		config     = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		status *Status
+		err    error
+	)
+
+	nodeClient = NewClient(config, http.DefaultClient)
+
+	if status, err = nodeClient.Status(context.Background()); err != nil {
+		panic(fmt.Sprintf("unable to get raiden node status: %s", err.Error()))
+	}
+
+	fmt.Println("raiden node ready:", status.IsReady())
+}
+
+func TestStatusGetter(t *testing.T) {
+	var (
+		localhostIP = "[::1]"
+		config      = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+	)
+
+	if os.Getenv("USE_IPV4") != "" {
+		localhostIP = "127.0.0.1"
+	}
+
+	type testcase struct {
+		name           string
+		prepHTTPMock   func()
+		expectedStatus *Status
+		expectedError  error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "successfully got ready status",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/status",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						`{"status":"ready"}`,
+					),
+				)
+			},
+			expectedError: nil,
+			expectedStatus: &Status{
+				Status: StatusReady,
+			},
+		},
+		testcase{
+			name: "successfully got syncing status",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/status",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						`{"status":"syncing","blocks_to_sync":"130"}`,
+					),
+				)
+			},
+			expectedError: nil,
+			expectedStatus: &Status{
+				Status:       StatusSyncing,
+				BlocksToSync: int64(130),
+			},
+		},
+		testcase{
+			name: "unexpected 500 response",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/status",
+					httpmock.NewStringResponder(
+						http.StatusInternalServerError,
+						``,
+					),
+				)
+			},
+			expectedError:  &util.APIError{StatusCode: http.StatusInternalServerError, Method: "GET", URL: "http://localhost:5001/api/v1/status"},
+			expectedStatus: nil,
+		},
+		testcase{
+			name: "unable to make http request",
+			prepHTTPMock: func() {
+				httpmock.Deactivate()
+			},
+			expectedError:  fmt.Errorf("Get http://localhost:5001/api/v1/status: dial tcp %s:5001: connect: connection refused", localhostIP),
+			expectedStatus: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				err          error
+				status       *Status
+				statusGetter = NewStatusGetter(config, http.DefaultClient)
+				ctx          = context.Background()
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			tc.prepHTTPMock()
+
+			status, err = statusGetter.Status(ctx)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, status)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package node
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+)
+
+type versionResponse struct {
+	Version string `json:"version"`
+}
+
+// VersionGetter is a generic interface to get the version of the Raiden software
+// a node is running. It allows for a context to be passed to allow for request
+// timeouts and/or deadlines on the response.
+type VersionGetter interface {
+	Version(ctx context.Context) (string, error)
+}
+
+var _ VersionGetter = &defaultVersionGetter{}
+
+// NewVersionGetter will return a default version getter for a configured Raiden
+// node.
+func NewVersionGetter(config *config.Config, httpClient *http.Client) VersionGetter {
+	return &defaultVersionGetter{
+		baseClient: &util.BaseClient{
+			Config:     config,
+			HTTPClient: httpClient,
+		},
+	}
+}
+
+type defaultVersionGetter struct {
+	baseClient *util.BaseClient
+}
+
+// Version will return the version of the Raiden software the node is running.
+func (getter *defaultVersionGetter) Version(ctx context.Context) (string, error) {
+	var (
+		err             error
+		versionResponse = &versionResponse{}
+	)
+
+	if err = getter.baseClient.Do(ctx, "GET", []string{"version"}, nil, versionResponse); err != nil {
+		return "", err
+	}
+
+	return versionResponse.Version, nil
+}
@@ -0,0 +1,123 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleVersionGetter() {
+	var (
+		nodeClient *Client
+		config     = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		version string
+		err     error
+	)
+
+	nodeClient = NewClient(config, http.DefaultClient)
+
+	if version, err = nodeClient.Version(context.Background()); err != nil {
+		panic(fmt.Sprintf("unable to get raiden node version: %s", err.Error()))
+	}
+
+	fmt.Println("raiden version:", version)
+}
+
+func TestVersionGetter(t *testing.T) {
+	var (
+		localhostIP = "[::1]"
+		config      = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+	)
+
+	if os.Getenv("USE_IPV4") != "" {
+		localhostIP = "127.0.0.1"
+	}
+
+	type testcase struct {
+		name            string
+		prepHTTPMock    func()
+		expectedVersion string
+		expectedError   error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "successfully got version",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/version",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						`{"version":"0.200.0"}`,
+					),
+				)
+			},
+			expectedError:   nil,
+			expectedVersion: "0.200.0",
+		},
+		testcase{
+			name: "unexpected 500 response",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/version",
+					httpmock.NewStringResponder(
+						http.StatusInternalServerError,
+						``,
+					),
+				)
+			},
+			expectedError:   &util.APIError{StatusCode: http.StatusInternalServerError, Method: "GET", URL: "http://localhost:5001/api/v1/version"},
+			expectedVersion: "",
+		},
+		testcase{
+			name: "unable to make http request",
+			prepHTTPMock: func() {
+				httpmock.Deactivate()
+			},
+			expectedError:   fmt.Errorf("Get http://localhost:5001/api/v1/version: dial tcp %s:5001: connect: connection refused", localhostIP),
+			expectedVersion: "",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				err           error
+				version       string
+				versionGetter = NewVersionGetter(config, http.DefaultClient)
+				ctx           = context.Background()
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			tc.prepHTTPMock()
+
+			version, err = versionGetter.Version(ctx)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedVersion, version)
+		})
+	}
+}
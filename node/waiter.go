@@ -0,0 +1,70 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultReadyPollInterval is the interval a Waiter polls the node status if no
+// interval is given.
+const DefaultReadyPollInterval = time.Second
+
+// Waiter is an interface to block until a Raiden node is ready to accept
+// requests.
+type Waiter interface {
+	WaitUntilReady(ctx context.Context) error
+}
+
+var _ Waiter = &defaultWaiter{}
+
+// NewWaiter will create a default waiter that polls the given status getter every
+// poll interval. If the poll interval is not positive DefaultReadyPollInterval is
+// used.
+func NewWaiter(statusGetter StatusGetter, pollInterval time.Duration) Waiter {
+	if pollInterval <= 0 {
+		pollInterval = DefaultReadyPollInterval
+	}
+
+	return &defaultWaiter{
+		statusGetter: statusGetter,
+		pollInterval: pollInterval,
+	}
+}
+
+type defaultWaiter struct {
+	statusGetter StatusGetter
+	pollInterval time.Duration
+}
+
+// WaitUntilReady will poll the node status until the node reports it is ready or
+// the context is done. Errors while polling, e.g. because the node has not started
+// listening yet, are not fatal; the last one is included in the error returned
+// once the context is done.
+func (waiter *defaultWaiter) WaitUntilReady(ctx context.Context) error {
+	var (
+		ticker  = time.NewTicker(waiter.pollInterval)
+		lastErr error
+	)
+
+	defer ticker.Stop()
+
+	for {
+		status, err := waiter.statusGetter.Status(ctx)
+
+		switch {
+		case err != nil:
+			lastErr = err
+		case status.IsReady():
+			return nil
+		default:
+			lastErr = fmt.Errorf("node is %s with %d blocks to sync", status.Status, status.BlocksToSync)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("node not ready: %s: %s", ctx.Err().Error(), lastErr.Error())
+		case <-ticker.C:
+		}
+	}
+}
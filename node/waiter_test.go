@@ -0,0 +1,87 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// scriptedStatusGetter returns the scripted results in order and repeats the last
+// one once the script is exhausted.
+type scriptedStatusGetter struct {
+	statuses []*Status
+	errs     []error
+	calls    int
+}
+
+func (getter *scriptedStatusGetter) Status(ctx context.Context) (*Status, error) {
+	index := getter.calls
+
+	if index >= len(getter.statuses) {
+		index = len(getter.statuses) - 1
+	}
+
+	getter.calls++
+
+	return getter.statuses[index], getter.errs[index]
+}
+
+func TestWaiter(t *testing.T) {
+	type testcase struct {
+		name          string
+		statusGetter  *scriptedStatusGetter
+		expectedCalls int
+		expectedError string
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "ready immediately",
+			statusGetter: &scriptedStatusGetter{
+				statuses: []*Status{&Status{Status: StatusReady}},
+				errs:     []error{nil},
+			},
+			expectedCalls: 1,
+		},
+		testcase{
+			name: "ready after syncing and connection errors",
+			statusGetter: &scriptedStatusGetter{
+				statuses: []*Status{nil, &Status{Status: StatusSyncing, BlocksToSync: 10}, &Status{Status: StatusReady}},
+				errs:     []error{errors.New("connection refused"), nil, nil},
+			},
+			expectedCalls: 3,
+		},
+		testcase{
+			name: "context done while syncing",
+			statusGetter: &scriptedStatusGetter{
+				statuses: []*Status{&Status{Status: StatusSyncing, BlocksToSync: 10}},
+				errs:     []error{nil},
+			},
+			expectedError: "node not ready: context deadline exceeded: node is syncing with 10 blocks to sync",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				waiter      = NewWaiter(tc.statusGetter, time.Millisecond)
+				ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+			)
+
+			defer cancel()
+
+			err := waiter.WaitUntilReady(ctx)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCalls, tc.statusGetter.calls)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package pathfinding
+
+import (
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/address"
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/tokens"
+)
+
+var (
+	_ Quoter    = &Client{}
+	_ FeeQuoter = &Client{}
+)
+
+// NewClient creates a new Pathfinding client for the pathfinding service in the
+// config, whose API version is the version of the service's API. Token networks
+// and the initiator are resolved with the getters of the Raiden node, e.g. its
+// Tokens and Address sub-clients.
+func NewClient(config *config.Config, httpClient *http.Client, tokenGetter tokens.Getter, addressGetter address.Getter) *Client {
+	var (
+		quoter = NewQuoter(config, httpClient, tokenGetter)
+	)
+
+	return &Client{
+		Quoter:    quoter,
+		FeeQuoter: NewFeeQuoter(quoter, addressGetter),
+	}
+}
+
+// Client allows for route and fee quotes from a pathfinding service.
+type Client struct {
+	Quoter
+	FeeQuoter
+}
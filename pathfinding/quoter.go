@@ -0,0 +1,164 @@
+package pathfinding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/address"
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/tokens"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultMaxPaths is the number of routes requested from the pathfinding service
+// if no maximum is given.
+const DefaultMaxPaths = 3
+
+type pathsRequest struct {
+	From     common.Address `json:"from"`
+	To       common.Address `json:"to"`
+	Value    *util.Amount   `json:"value"`
+	MaxPaths int            `json:"max_paths"`
+}
+
+type pathsResponse struct {
+	Result        []*Route `json:"result"`
+	FeedbackToken string   `json:"feedback_token"`
+}
+
+// QuoteOptions holds the optional parameters of a route quote.
+type QuoteOptions struct {
+	// MaxPaths is the maximum number of routes returned by the service.
+	MaxPaths int
+}
+
+// Quoter is an interface to request routes and their estimated fees for a
+// payment from a pathfinding service.
+type Quoter interface {
+	Quote(ctx context.Context, tokenAddress, initiatorAddress, targetAddress common.Address, amount *util.Amount, options *QuoteOptions) (*Quote, error)
+}
+
+// FeeQuoter is an interface to estimate the fee of a payment from the Raiden node
+// to a target.
+type FeeQuoter interface {
+	QuoteFee(ctx context.Context, tokenAddress, targetAddress common.Address, amount *util.Amount) (*util.Amount, error)
+}
+
+var (
+	_ Quoter    = &defaultQuoter{}
+	_ FeeQuoter = &defaultFeeQuoter{}
+)
+
+// NewQuoter will create a default quoter for the pathfinding service in the
+// config that resolves token network addresses with the token getter of the
+// Raiden node.
+func NewQuoter(config *config.Config, httpClient *http.Client, tokenGetter tokens.Getter) Quoter {
+	return &defaultQuoter{
+		baseClient: &util.BaseClient{
+			Config:     config,
+			HTTPClient: httpClient,
+		},
+		tokenGetter: tokenGetter,
+	}
+}
+
+type defaultQuoter struct {
+	baseClient  *util.BaseClient
+	tokenGetter tokens.Getter
+}
+
+// Quote will look up the token network of the token on the Raiden node and ask the
+// pathfinding service for routes from the initiator to the target that can carry
+// the amount. Options may be nil in which case DefaultMaxPaths routes are
+// requested. Pathfinding services that charge for requests are not supported.
+func (quoter *defaultQuoter) Quote(ctx context.Context, tokenAddress, initiatorAddress, targetAddress common.Address, amount *util.Amount, options *QuoteOptions) (*Quote, error) {
+	var (
+		err                 error
+		tokenNetworkAddress common.Address
+		response            = &pathsResponse{}
+		request             = &pathsRequest{
+			From:     initiatorAddress,
+			To:       targetAddress,
+			Value:    amount,
+			MaxPaths: DefaultMaxPaths,
+		}
+	)
+
+	if amount.Sign() <= 0 {
+		return nil, errors.New("payment amount must be greater than zero")
+	}
+
+	if options != nil && options.MaxPaths < 0 {
+		return nil, errors.New("maximum number of paths must not be negative")
+	}
+
+	if options != nil && options.MaxPaths > 0 {
+		request.MaxPaths = options.MaxPaths
+	}
+
+	if tokenNetworkAddress, err = quoter.tokenGetter.Get(ctx, tokenAddress); err != nil {
+		return nil, fmt.Errorf("unable to resolve token network of token %s: %s", tokenAddress.Hex(), err.Error())
+	}
+
+	// the service is only asked and never changes state, so the quote is safe to retry
+	if err = quoter.baseClient.Do(util.WithIdempotent(ctx), "POST", []string{tokenNetworkAddress.Hex(), "paths"}, request, response); err != nil {
+		return nil, err
+	}
+
+	if response.Result == nil {
+		response.Result = make([]*Route, 0)
+	}
+
+	return &Quote{
+		TokenAddress:        tokenAddress,
+		TokenNetworkAddress: tokenNetworkAddress,
+		InitiatorAddress:    initiatorAddress,
+		TargetAddress:       targetAddress,
+		Amount:              amount,
+		Routes:              response.Result,
+		FeedbackToken:       response.FeedbackToken,
+	}, nil
+}
+
+// NewFeeQuoter will create a default fee quoter that quotes payments from the
+// address of the Raiden node returned by the address getter.
+func NewFeeQuoter(quoter Quoter, addressGetter address.Getter) FeeQuoter {
+	return &defaultFeeQuoter{
+		quoter:        quoter,
+		addressGetter: addressGetter,
+	}
+}
+
+type defaultFeeQuoter struct {
+	quoter        Quoter
+	addressGetter address.Getter
+}
+
+// QuoteFee will return the estimated fee of the cheapest route from the Raiden
+// node to the target. An error is returned if the service found no route.
+func (feeQuoter *defaultFeeQuoter) QuoteFee(ctx context.Context, tokenAddress, targetAddress common.Address, amount *util.Amount) (*util.Amount, error) {
+	var (
+		err              error
+		initiatorAddress common.Address
+		quote            *Quote
+	)
+
+	if initiatorAddress, err = feeQuoter.addressGetter.Get(ctx); err != nil {
+		return nil, fmt.Errorf("unable to get node address: %s", err.Error())
+	}
+
+	if quote, err = feeQuoter.quoter.Quote(ctx, tokenAddress, initiatorAddress, targetAddress, amount, nil); err != nil {
+		return nil, err
+	}
+
+	cheapest := quote.Cheapest()
+
+	if cheapest == nil {
+		return nil, fmt.Errorf("no route to %s for %s of token %s", targetAddress.Hex(), amount, tokenAddress.Hex())
+	}
+
+	return cheapest.EstimatedFee, nil
+}
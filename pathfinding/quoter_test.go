@@ -0,0 +1,246 @@
+package pathfinding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/address"
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/tokens"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleQuoter() {
+	var (
+		pathfindingClient *Client
+		nodeConfig        = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		pathfindingConfig = &config.Config{
+			Host:       "https://pfs.raiden.network",
+			APIVersion: "v1",
+		}
+		tokenAddress     = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359") // DAI Stablecoin
+		initiatorAddress = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+		targetAddress    = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
+		quote            *Quote
+		err              error
+	)
+
+	pathfindingClient = NewClient(pathfindingConfig, http.DefaultClient, tokens.NewGetter(nodeConfig, http.DefaultClient), address.NewGetter(nodeConfig, http.DefaultClient))
+
+	if quote, err = pathfindingClient.Quote(context.Background(), tokenAddress, initiatorAddress, targetAddress, util.NewAmount(1000000), nil); err != nil {
+		panic(fmt.Sprintf("unable to quote payment: %s", err.Error()))
+	}
+
+	for _, route := range quote.Routes {
+		fmt.Printf("%d hops for a fee of %s\n", route.Hops(), route.EstimatedFee)
+	}
+}
+
+func TestQuoter(t *testing.T) {
+	var (
+		localhostIP = "[::1]"
+		nodeConfig  = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		pathfindingConfig = &config.Config{
+			Host:       "http://localhost:6000",
+			APIVersion: "v1",
+		}
+		tokenURL = "http://localhost:5001/api/v1/tokens/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"
+		pathsURL = "http://localhost:6000/api/v1/0x61C808D82A3Ac53231750daDc13c777b59310bD9/paths"
+
+		initiator = common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226")
+		mediator  = common.HexToAddress("0x7a38bB1e7d6E1F1ABb2f2da2E3B0b2EBA9E48D75")
+		target    = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	)
+
+	if os.Getenv("USE_IPV4") != "" {
+		localhostIP = "127.0.0.1"
+	}
+
+	registerTokenNetwork := func() {
+		httpmock.RegisterResponder(
+			"GET",
+			tokenURL,
+			httpmock.NewStringResponder(
+				http.StatusOK,
+				`"0x61C808D82A3Ac53231750daDc13c777b59310bD9"`,
+			),
+		)
+	}
+
+	type testcase struct {
+		name           string
+		prepHTTPMock   func(requests *[]*pathsRequest)
+		options        *QuoteOptions
+		expectedRoutes []*Route
+		expectedPaths  int
+		expectedError  error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "successfully quoted payment",
+			prepHTTPMock: func(requests *[]*pathsRequest) {
+				registerTokenNetwork()
+
+				httpmock.RegisterResponder("POST", pathsURL, func(request *http.Request) (*http.Response, error) {
+					pathsRequest := &pathsRequest{}
+
+					if err := json.NewDecoder(request.Body).Decode(pathsRequest); err != nil {
+						return nil, err
+					}
+
+					*requests = append(*requests, pathsRequest)
+
+					return httpmock.NewStringResponse(
+						http.StatusOK,
+						`{"result":[{"path":["0x2a65Aca4D5fC5B5C859090a6c34d164135398226","0x7a38bB1e7d6E1F1ABb2f2da2E3B0b2EBA9E48D75","0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"],"estimated_fee":150},{"path":["0x2a65Aca4D5fC5B5C859090a6c34d164135398226","0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"],"estimated_fee":0}],"feedback_token":"9b32fb2b9e0f4ed4b7a8a4fa2e8e7c41"}`,
+					), nil
+				})
+			},
+			options: &QuoteOptions{MaxPaths: 5},
+			expectedRoutes: []*Route{
+				&Route{Path: []common.Address{initiator, mediator, target}, EstimatedFee: util.NewAmount(150)},
+				&Route{Path: []common.Address{initiator, target}, EstimatedFee: util.NewAmount(0)},
+			},
+			expectedPaths: 5,
+		},
+		testcase{
+			name: "no route found",
+			prepHTTPMock: func(requests *[]*pathsRequest) {
+				registerTokenNetwork()
+
+				httpmock.RegisterResponder(
+					"POST",
+					pathsURL,
+					httpmock.NewStringResponder(
+						http.StatusBadRequest,
+						`{"errors":"No suitable path found for transfer from 0x2a65 to 0xA0b8.","error_code":2201}`,
+					),
+				)
+			},
+			expectedError: &util.APIError{StatusCode: http.StatusBadRequest, Method: "POST", URL: pathsURL, Errors: []string{"No suitable path found for transfer from 0x2a65 to 0xA0b8."}},
+		},
+		testcase{
+			name: "unknown token",
+			prepHTTPMock: func(requests *[]*pathsRequest) {
+				httpmock.RegisterResponder(
+					"GET",
+					tokenURL,
+					httpmock.NewStringResponder(
+						http.StatusNotFound,
+						``,
+					),
+				)
+			},
+			expectedError: errors.New("unable to resolve token network of token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8: GET " + tokenURL + ": 404 Not Found"),
+		},
+		testcase{
+			name: "negative maximum paths",
+			prepHTTPMock: func(requests *[]*pathsRequest) {
+			},
+			options:       &QuoteOptions{MaxPaths: -1},
+			expectedError: errors.New("maximum number of paths must not be negative"),
+		},
+		testcase{
+			name: "unable to make http request",
+			prepHTTPMock: func(requests *[]*pathsRequest) {
+				httpmock.Deactivate()
+			},
+			expectedError: fmt.Errorf("unable to resolve token network of token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8: Get %s: dial tcp %s:5001: connect: connection refused", tokenURL, localhostIP),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				err          error
+				quote        *Quote
+				requests     = make([]*pathsRequest, 0)
+				tokenAddress = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+				amount       = util.NewAmount(1000000)
+
+				quoter = NewQuoter(pathfindingConfig, http.DefaultClient, tokens.NewGetter(nodeConfig, http.DefaultClient))
+				ctx    = context.Background()
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			tc.prepHTTPMock(&requests)
+
+			quote, err = quoter.Quote(ctx, tokenAddress, initiator, target, amount, tc.options)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRoutes, quote.Routes)
+			assert.Equal(t, common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9"), quote.TokenNetworkAddress)
+			assert.Equal(t, "9b32fb2b9e0f4ed4b7a8a4fa2e8e7c41", quote.FeedbackToken)
+			assert.Equal(t, tc.expectedRoutes[1], quote.Cheapest())
+			assert.Equal(t, 2, quote.Routes[0].Hops())
+
+			require.Len(t, requests, 1)
+			assert.Equal(t, &pathsRequest{From: initiator, To: target, Value: amount, MaxPaths: tc.expectedPaths}, requests[0])
+		})
+	}
+}
+
+type fakeQuoter struct {
+	quote *Quote
+}
+
+func (quoter *fakeQuoter) Quote(ctx context.Context, tokenAddress, initiatorAddress, targetAddress common.Address, amount *util.Amount, options *QuoteOptions) (*Quote, error) {
+	quote := *quoter.quote
+	quote.InitiatorAddress = initiatorAddress
+
+	return &quote, nil
+}
+
+type fakeAddressGetter struct {
+	address common.Address
+}
+
+func (getter *fakeAddressGetter) Get(ctx context.Context) (common.Address, error) {
+	return getter.address, nil
+}
+
+func TestFeeQuoter(t *testing.T) {
+	var (
+		nodeAddress   = common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226")
+		tokenAddress  = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+		targetAddress = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	)
+
+	quoter := &fakeQuoter{quote: &Quote{Routes: []*Route{
+		&Route{EstimatedFee: util.NewAmount(30)},
+		&Route{EstimatedFee: util.NewAmount(12)},
+		&Route{EstimatedFee: util.NewAmount(12)},
+	}}}
+
+	fee, err := NewFeeQuoter(quoter, &fakeAddressGetter{address: nodeAddress}).QuoteFee(context.Background(), tokenAddress, targetAddress, util.NewAmount(100))
+	require.NoError(t, err)
+	assert.Equal(t, util.NewAmount(12), fee)
+
+	quoter.quote.Routes = []*Route{}
+
+	_, err = NewFeeQuoter(quoter, &fakeAddressGetter{address: nodeAddress}).QuoteFee(context.Background(), tokenAddress, targetAddress, util.NewAmount(100))
+	assert.EqualError(t, err, "no route to 0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48 for 100 of token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+}
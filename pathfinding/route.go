@@ -0,0 +1,53 @@
+package pathfinding
+
+import (
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Route is a candidate path for a payment through the token network along with
+// the fee the pathfinding service expects the mediators to charge. The path
+// starts with the initiator and ends with the target.
+type Route struct {
+	Path         []common.Address `json:"path"`
+	EstimatedFee *util.Amount     `json:"estimated_fee"`
+}
+
+// Hops returns the number of channels the payment passes along the route.
+func (route *Route) Hops() int {
+	if len(route.Path) == 0 {
+		return 0
+	}
+
+	return len(route.Path) - 1
+}
+
+// Quote holds the routes a pathfinding service returned for a payment, in the
+// order of preference of the service.
+type Quote struct {
+	TokenAddress        common.Address
+	TokenNetworkAddress common.Address
+	InitiatorAddress    common.Address
+	TargetAddress       common.Address
+	Amount              *util.Amount
+	Routes              []*Route
+	// FeedbackToken identifies the request towards the pathfinding service when
+	// reporting whether a route worked.
+	FeedbackToken string
+}
+
+// Cheapest returns the route with the lowest estimated fee, preferring the
+// service's order between routes with the same fee, or nil if there is no route.
+func (quote *Quote) Cheapest() *Route {
+	var (
+		cheapest *Route
+	)
+
+	for _, route := range quote.Routes {
+		if cheapest == nil || route.EstimatedFee.Cmp(cheapest.EstimatedFee) < 0 {
+			cheapest = route
+		}
+	}
+
+	return cheapest
+}
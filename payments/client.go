@@ -4,21 +4,52 @@ import (
 	"net/http"
 
 	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/pathfinding"
+	"github.com/cpurta/go-raiden-client/pending_transfers"
 )
 
 var (
-	_ Lister    = &Client{}
-	_ Initiator = &Client{}
+	_ Lister             = &Client{}
+	_ Initiator          = &Client{}
+	_ Subscriber         = &Client{}
+	_ IdempotentPayer    = &Client{}
+	_ FeeCappedInitiator = &Client{}
 )
 
+// NewClient creates a new Payments client that is able to initiate payments,
+// idempotently pay, list payment events and subscribe to new payment events of a
+// Raiden node.
 func NewClient(config *config.Config, httpClient *http.Client) *Client {
+	var (
+		lister    = NewLister(config, httpClient)
+		initiator = NewInitiator(config, httpClient)
+	)
+
 	return &Client{
-		Lister:    NewLister(config, httpClient),
-		Initiator: NewInitiator(config, httpClient),
+		Lister:             lister,
+		Initiator:          initiator,
+		Subscriber:         NewSubscriber(lister, nil),
+		IdempotentPayer:    NewIdempotentPayer(initiator, lister, pendingtransfers.NewLister(config, httpClient), nil),
+		FeeCappedInitiator: &unconfiguredFeeCappedInitiator{},
 	}
 }
 
+// Client allows for initiate, pay, list and subscribe operations for a Raiden node.
+// Fee capped payments return an error until a fee quoter is set with
+// WithFeeQuoter.
 type Client struct {
 	Lister
 	Initiator
+	Subscriber
+	IdempotentPayer
+	FeeCappedInitiator
+}
+
+// WithFeeQuoter sets up the client's fee capped initiator, which checks the fee
+// cap of a payment against the fees quoted by the fee quoter, e.g. a pathfinding
+// client, and returns the client.
+func (client *Client) WithFeeQuoter(feeQuoter pathfinding.FeeQuoter) *Client {
+	client.FeeCappedInitiator = NewFeeCappedInitiator(client.Initiator, feeQuoter)
+
+	return client
 }
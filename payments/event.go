@@ -1,25 +1,101 @@
 package payments
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/cpurta/go-raiden-client/util"
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// EventType is the kind of payment event reported by a Raiden node.
+type EventType string
+
+const (
+	// EventPaymentSentSuccess is reported when a payment sent by the node was
+	// successfully completed.
+	EventPaymentSentSuccess EventType = "EventPaymentSentSuccess"
+	// EventPaymentSentFailed is reported when a payment sent by the node failed.
+	// The reason for the failure is given in the event Reason.
+	EventPaymentSentFailed EventType = "EventPaymentSentFailed"
+	// EventPaymentReceivedSuccess is reported when the node received a payment.
+	EventPaymentReceivedSuccess EventType = "EventPaymentReceivedSuccess"
+)
+
+// logTimeLayouts are the layouts a Raiden node may use for an event log time.
+// Older nodes omit the timezone, in which case the time is in UTC.
+var logTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+}
+
 type event struct {
-	EventName  string `json:"event"`
-	Amount     int64  `json:"amount"`
-	Initiator  string `json:"initiator"`
-	Target     string `json:"target"`
-	Identifier int64  `json:"identifier"`
-	LogTime    string `json:"log_time"`
+	EventName    EventType    `json:"event"`
+	Amount       *util.Amount `json:"amount"`
+	Initiator    string       `json:"initiator"`
+	Target       string       `json:"target"`
+	TokenAddress string       `json:"token_address"`
+	Identifier   uint64       `json:"identifier"`
+	Reason       string       `json:"reason"`
+	LogTime      string       `json:"log_time"`
 }
 
+// Event is a payment sent or received by a Raiden node, or a failed attempt to
+// send a payment.
 type Event struct {
-	EventName  string
-	Amount     int64
-	Initiator  common.Address
-	Target     common.Address
-	Identifier int64
-	LogTime    time.Time
+	EventName    EventType
+	Amount       *util.Amount
+	Initiator    common.Address
+	Target       common.Address
+	TokenAddress common.Address
+	Identifier   uint64
+	Reason       string
+	LogTime      time.Time
+}
+
+// IsSent returns true if the event is about a payment sent by the node.
+func (event *Event) IsSent() bool {
+	return event.EventName == EventPaymentSentSuccess || event.EventName == EventPaymentSentFailed
+}
+
+// IsReceived returns true if the event is about a payment received by the node.
+func (event *Event) IsReceived() bool {
+	return event.EventName == EventPaymentReceivedSuccess
+}
+
+// IsFailed returns true if the event is about a failed payment.
+func (event *Event) IsFailed() bool {
+	return event.EventName == EventPaymentSentFailed
+}
+
+func (event *event) toEvent() (*Event, error) {
+	var (
+		err     error
+		logTime time.Time
+	)
+
+	if logTime, err = parseLogTime(event.LogTime); err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		EventName:    event.EventName,
+		Amount:       event.Amount,
+		Initiator:    common.HexToAddress(event.Initiator),
+		Target:       common.HexToAddress(event.Target),
+		TokenAddress: common.HexToAddress(event.TokenAddress),
+		Identifier:   event.Identifier,
+		Reason:       event.Reason,
+		LogTime:      logTime,
+	}, nil
+}
+
+func parseLogTime(value string) (time.Time, error) {
+	for _, layout := range logTimeLayouts {
+		if logTime, err := time.Parse(layout, value); err == nil {
+			return logTime, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse payment event log time: %q", value)
 }
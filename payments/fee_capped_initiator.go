@@ -0,0 +1,104 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cpurta/go-raiden-client/pathfinding"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	errNoFeeQuoter = errors.New("no fee quoter configured; use WithFeeQuoter or WithPathfinding")
+)
+
+// FeeCapError is returned by a fee capped initiator if the estimated fee of a
+// payment exceeds its fee cap. The payment is not sent.
+type FeeCapError struct {
+	EstimatedFee *util.Amount
+	MaxFee       *util.Amount
+}
+
+func (err *FeeCapError) Error() string {
+	return fmt.Sprintf("estimated fee %s exceeds the fee cap %s", err.EstimatedFee, err.MaxFee)
+}
+
+// FeeCappedOptions holds the optional parameters of a fee capped payment.
+type FeeCappedOptions struct {
+	InitiateOptions
+	// MaxFee is the highest fee the payment may cost. The node does not take a
+	// fee cap, so it is checked against a fee quote before the payment is sent.
+	MaxFee *util.Amount
+}
+
+// FeeCappedInitiator is an interface to initiate a payment only if its estimated
+// fee does not exceed a fee cap.
+type FeeCappedInitiator interface {
+	InitiateFeeCapped(ctx context.Context, tokenAddress, targetAddress common.Address, amount *util.Amount, options *FeeCappedOptions) (*Payment, error)
+}
+
+var _ FeeCappedInitiator = &feeCappedInitiator{}
+
+// NewFeeCappedInitiator will create an initiator that checks the MaxFee option
+// of a payment against the fee quoted by the fee quoter, e.g. a pathfinding
+// client, before initiating it with the given initiator.
+func NewFeeCappedInitiator(initiator Initiator, feeQuoter pathfinding.FeeQuoter) FeeCappedInitiator {
+	return &feeCappedInitiator{
+		initiator: initiator,
+		feeQuoter: feeQuoter,
+	}
+}
+
+type feeCappedInitiator struct {
+	initiator Initiator
+	feeQuoter pathfinding.FeeQuoter
+}
+
+// InitiateFeeCapped will quote the fee of the payment if options has a MaxFee and
+// return a *FeeCapError without paying if the estimated fee exceeds it. The node
+// may still route the payment differently than quoted, so the fee cap bounds the
+// expected and not the actual fee.
+func (initiator *feeCappedInitiator) InitiateFeeCapped(ctx context.Context, tokenAddress, targetAddress common.Address, amount *util.Amount, options *FeeCappedOptions) (*Payment, error) {
+	var (
+		err          error
+		estimatedFee *util.Amount
+	)
+
+	if options == nil {
+		return initiator.initiator.Initiate(ctx, tokenAddress, targetAddress, amount, nil)
+	}
+
+	if options.MaxFee == nil {
+		return initiator.initiator.Initiate(ctx, tokenAddress, targetAddress, amount, &options.InitiateOptions)
+	}
+
+	if options.MaxFee.Sign() < 0 {
+		return nil, errors.New("fee cap must not be negative")
+	}
+
+	if _, err = newInitiatePaymentRequest(amount, &options.InitiateOptions); err != nil {
+		return nil, err
+	}
+
+	if estimatedFee, err = initiator.feeQuoter.QuoteFee(ctx, tokenAddress, targetAddress, amount); err != nil {
+		return nil, fmt.Errorf("unable to quote payment fee: %s", err.Error())
+	}
+
+	if estimatedFee.Cmp(options.MaxFee) > 0 {
+		return nil, &FeeCapError{EstimatedFee: estimatedFee, MaxFee: options.MaxFee}
+	}
+
+	return initiator.initiator.Initiate(ctx, tokenAddress, targetAddress, amount, &options.InitiateOptions)
+}
+
+// unconfiguredFeeCappedInitiator is the fee capped initiator of a client without
+// a fee quoter. It fails every payment instead of paying without a fee check.
+type unconfiguredFeeCappedInitiator struct{}
+
+var _ FeeCappedInitiator = &unconfiguredFeeCappedInitiator{}
+
+func (initiator *unconfiguredFeeCappedInitiator) InitiateFeeCapped(ctx context.Context, tokenAddress, targetAddress common.Address, amount *util.Amount, options *FeeCappedOptions) (*Payment, error) {
+	return nil, errNoFeeQuoter
+}
@@ -0,0 +1,153 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/address"
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/pathfinding"
+	"github.com/cpurta/go-raiden-client/tokens"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleNewFeeCappedInitiator() {
+	var (
+		nodeConfig = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		pathfindingConfig = &config.Config{
+			Host:       "https://pfs.raiden.network",
+			APIVersion: "v1",
+		}
+		tokenAddress  = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359") // DAI Stablecoin
+		targetAddress = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
+		payment       *Payment
+		err           error
+	)
+
+	pathfindingClient := pathfinding.NewClient(pathfindingConfig, http.DefaultClient, tokens.NewGetter(nodeConfig, http.DefaultClient), address.NewGetter(nodeConfig, http.DefaultClient))
+	initiator := NewFeeCappedInitiator(NewInitiator(nodeConfig, http.DefaultClient), pathfindingClient)
+
+	if payment, err = initiator.InitiateFeeCapped(context.Background(), tokenAddress, targetAddress, util.NewAmount(1000000), &FeeCappedOptions{MaxFee: util.NewAmount(500)}); err != nil {
+		panic(fmt.Sprintf("unable to pay: %s", err.Error()))
+	}
+
+	fmt.Printf("paid %s\n", payment.Amount)
+}
+
+type fakeFeeQuoter struct {
+	fee *util.Amount
+	err error
+}
+
+func (quoter *fakeFeeQuoter) QuoteFee(ctx context.Context, tokenAddress, targetAddress common.Address, amount *util.Amount) (*util.Amount, error) {
+	return quoter.fee, quoter.err
+}
+
+type recordingInitiator struct {
+	options []*InitiateOptions
+}
+
+func (initiator *recordingInitiator) Initiate(ctx context.Context, tokenAddress, targetAddress common.Address, amount *util.Amount, options *InitiateOptions) (*Payment, error) {
+	initiator.options = append(initiator.options, options)
+
+	return &Payment{Amount: amount}, nil
+}
+
+func TestFeeCappedInitiator(t *testing.T) {
+	var (
+		tokenAddress  = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+		targetAddress = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
+	)
+
+	type testcase struct {
+		name            string
+		feeQuoter       *fakeFeeQuoter
+		options         *FeeCappedOptions
+		expectedOptions []*InitiateOptions
+		expectedError   error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:            "no fee cap",
+			feeQuoter:       &fakeFeeQuoter{err: errors.New("must not be called")},
+			options:         &FeeCappedOptions{InitiateOptions: InitiateOptions{Identifier: 42}},
+			expectedOptions: []*InitiateOptions{&InitiateOptions{Identifier: 42}},
+		},
+		testcase{
+			name:            "no options",
+			feeQuoter:       &fakeFeeQuoter{err: errors.New("must not be called")},
+			expectedOptions: []*InitiateOptions{nil},
+		},
+		testcase{
+			name:            "estimated fee within the fee cap",
+			feeQuoter:       &fakeFeeQuoter{fee: util.NewAmount(50)},
+			options:         &FeeCappedOptions{InitiateOptions: InitiateOptions{Identifier: 42}, MaxFee: util.NewAmount(50)},
+			expectedOptions: []*InitiateOptions{&InitiateOptions{Identifier: 42}},
+		},
+		testcase{
+			name:            "estimated fee exceeds the fee cap",
+			feeQuoter:       &fakeFeeQuoter{fee: util.NewAmount(51)},
+			options:         &FeeCappedOptions{MaxFee: util.NewAmount(50)},
+			expectedOptions: []*InitiateOptions{},
+			expectedError:   &FeeCapError{EstimatedFee: util.NewAmount(51), MaxFee: util.NewAmount(50)},
+		},
+		testcase{
+			name:            "unable to quote fee",
+			feeQuoter:       &fakeFeeQuoter{err: errors.New("no route")},
+			options:         &FeeCappedOptions{MaxFee: util.NewAmount(50)},
+			expectedOptions: []*InitiateOptions{},
+			expectedError:   errors.New("unable to quote payment fee: no route"),
+		},
+		testcase{
+			name:            "negative fee cap",
+			feeQuoter:       &fakeFeeQuoter{fee: util.NewAmount(0)},
+			options:         &FeeCappedOptions{MaxFee: util.NewAmount(-1)},
+			expectedOptions: []*InitiateOptions{},
+			expectedError:   errors.New("fee cap must not be negative"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				recorder  = &recordingInitiator{options: make([]*InitiateOptions, 0)}
+				initiator = NewFeeCappedInitiator(recorder, tc.feeQuoter)
+			)
+
+			_, err := initiator.InitiateFeeCapped(context.Background(), tokenAddress, targetAddress, util.NewAmount(1000), tc.options)
+
+			assert.Equal(t, tc.expectedOptions, recorder.options)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+
+	// the payments client fails fee capped payments until it has a fee quoter
+	paymentClient := NewClient(&config.Config{Host: "http://localhost:5001", APIVersion: "v1"}, http.DefaultClient)
+
+	_, err := paymentClient.InitiateFeeCapped(context.Background(), tokenAddress, targetAddress, util.NewAmount(1000), &FeeCappedOptions{MaxFee: util.NewAmount(50)})
+	assert.EqualError(t, err, "no fee quoter configured; use WithFeeQuoter or WithPathfinding")
+
+	paymentClient = paymentClient.WithFeeQuoter(&fakeFeeQuoter{fee: util.NewAmount(51)})
+
+	_, err = paymentClient.InitiateFeeCapped(context.Background(), tokenAddress, targetAddress, util.NewAmount(1000), &FeeCappedOptions{MaxFee: util.NewAmount(50)})
+	assert.EqualError(t, err, "estimated fee 51 exceeds the fee cap 50")
+
+	// an exceeded fee cap is definite, so an idempotent payer does not retry it
+	assert.False(t, isAmbiguous(&FeeCapError{EstimatedFee: util.NewAmount(51), MaxFee: util.NewAmount(50)}))
+}
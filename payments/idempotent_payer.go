@@ -0,0 +1,249 @@
+package payments
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cpurta/go-raiden-client/pending_transfers"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// DefaultPayAttempts is the number of times an IdempotentPayer sends a payment
+	// if no maximum is given.
+	DefaultPayAttempts = 3
+	// DefaultPayRetryInterval is the time an IdempotentPayer waits before it
+	// reconciles a payment that failed ambiguously if no interval is given.
+	DefaultPayRetryInterval = 2 * time.Second
+	// DefaultPayReconcileWindow is how far back an IdempotentPayer looks for
+	// payment events of a key if no window is given.
+	DefaultPayReconcileWindow = 24 * time.Hour
+)
+
+// ErrPaymentPending is returned by an IdempotentPayer if the node still has a
+// pending transfer for the payment. The payment must not be sent again; calling
+// Pay with the same key later returns the payment once it completed.
+var ErrPaymentPending = errors.New("payment is pending")
+
+// PaymentIdentifier derives a deterministic, non-zero payment identifier from an
+// idempotency key such as an order or payout ID.
+func PaymentIdentifier(key string) uint64 {
+	var (
+		hash       = sha256.Sum256([]byte(key))
+		identifier = binary.BigEndian.Uint64(hash[:8])
+	)
+
+	if identifier == 0 {
+		identifier = 1
+	}
+
+	return identifier
+}
+
+// IdempotentPayerOptions configures how often an IdempotentPayer sends a payment
+// that failed ambiguously.
+type IdempotentPayerOptions struct {
+	// MaxAttempts is the maximum number of times a payment is sent.
+	MaxAttempts int
+	// RetryInterval is the time waited before a payment that failed ambiguously
+	// is reconciled and sent again.
+	RetryInterval time.Duration
+	// ReconcileWindow is how long before a call to Pay its payment events are
+	// searched for an earlier payment with the same key. A key reused after the
+	// window is paid again.
+	ReconcileWindow time.Duration
+}
+
+// PaymentResult is the outcome of an idempotent payment. Payment is set if the
+// node accepted the payment in this call, Event is set if the payment had already
+// been completed and was found while reconciling.
+type PaymentResult struct {
+	Identifier uint64
+	Payment    *Payment
+	Event      *Event
+}
+
+// IdempotentPayer is an interface to pay a target at most once per idempotency
+// key, even if the caller retries after a timeout or a crash.
+type IdempotentPayer interface {
+	Pay(ctx context.Context, key string, tokenAddress, targetAddress common.Address, amount *util.Amount, options *InitiateOptions) (*PaymentResult, error)
+}
+
+var _ IdempotentPayer = &defaultIdempotentPayer{}
+
+// NewIdempotentPayer will create a default idempotent payer that initiates
+// payments with the initiator and reconciles them using the payment events of the
+// lister and the pending transfers of the transfer lister. If options is nil the
+// defaults are used.
+func NewIdempotentPayer(initiator Initiator, lister Lister, transferLister pendingtransfers.Lister, options *IdempotentPayerOptions) IdempotentPayer {
+	var (
+		payer = &defaultIdempotentPayer{
+			initiator:       initiator,
+			lister:          lister,
+			transferLister:  transferLister,
+			maxAttempts:     DefaultPayAttempts,
+			retryInterval:   DefaultPayRetryInterval,
+			reconcileWindow: DefaultPayReconcileWindow,
+		}
+	)
+
+	if options != nil && options.MaxAttempts > 0 {
+		payer.maxAttempts = options.MaxAttempts
+	}
+
+	if options != nil && options.RetryInterval > 0 {
+		payer.retryInterval = options.RetryInterval
+	}
+
+	if options != nil && options.ReconcileWindow > 0 {
+		payer.reconcileWindow = options.ReconcileWindow
+	}
+
+	return payer
+}
+
+type defaultIdempotentPayer struct {
+	initiator       Initiator
+	lister          Lister
+	transferLister  pendingtransfers.Lister
+	maxAttempts     int
+	retryInterval   time.Duration
+	reconcileWindow time.Duration
+}
+
+// Pay will pay the amount to the target using an identifier derived from the key.
+// Before every attempt the node's payment events and pending transfers are
+// checked for the identifier: a completed payment is returned without paying
+// again and a pending one returns ErrPaymentPending. A payment is only sent again
+// if the previous attempt failed ambiguously, e.g. because of a timeout, and no
+// trace of it was found on the node. A conflict is reconciled once more and
+// returned unless the payment turns out to be completed or pending.
+func (payer *defaultIdempotentPayer) Pay(ctx context.Context, key string, tokenAddress, targetAddress common.Address, amount *util.Amount, options *InitiateOptions) (*PaymentResult, error) {
+	var (
+		err             error
+		payment         *Payment
+		result          *PaymentResult
+		identifier      = PaymentIdentifier(key)
+		initiateOptions = InitiateOptions{}
+		since           = time.Now().Add(-payer.reconcileWindow)
+	)
+
+	if options != nil {
+		initiateOptions = *options
+	}
+
+	if initiateOptions.Identifier != 0 && initiateOptions.Identifier != identifier {
+		return nil, fmt.Errorf("payment identifier %d does not match the identifier %d of key %q", initiateOptions.Identifier, identifier, key)
+	}
+
+	initiateOptions.Identifier = identifier
+
+	// invalid options are rejected before anything is sent so that they are not
+	// mistaken for an ambiguous failure
+	if _, err = newInitiatePaymentRequest(amount, &initiateOptions); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		if result, err = payer.reconcile(ctx, identifier, tokenAddress, targetAddress, amount, since); result != nil || err != nil {
+			return result, err
+		}
+
+		if payment, err = payer.initiator.Initiate(ctx, tokenAddress, targetAddress, amount, &initiateOptions); err == nil {
+			return &PaymentResult{
+				Identifier: identifier,
+				Payment:    payment,
+			}, nil
+		}
+
+		// the node returns a conflict for a payment with an identifier already in
+		// flight, but also for a closed channel or an unknown token network, so it
+		// is only ambiguous if the payment is found on the node
+		if util.IsConflict(err) {
+			if result, reconcileErr := payer.reconcile(ctx, identifier, tokenAddress, targetAddress, amount, since); result != nil || reconcileErr != nil {
+				return result, reconcileErr
+			}
+
+			return nil, err
+		}
+
+		if !isAmbiguous(err) || attempt >= payer.maxAttempts {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, util.WrapError(err, "payment %d is in an unknown state", identifier)
+		case <-time.After(payer.retryInterval):
+		}
+	}
+}
+
+// reconcile looks for a completed or pending payment with the identifier, only
+// considering payment events logged since the given time. It returns a nil result
+// and error if no trace of the payment was found.
+func (payer *defaultIdempotentPayer) reconcile(ctx context.Context, identifier uint64, tokenAddress, targetAddress common.Address, amount *util.Amount, since time.Time) (*PaymentResult, error) {
+	var (
+		err       error
+		events    []*Event
+		transfers []*pendingtransfers.Transfer
+	)
+
+	if events, err = payer.lister.ListEvents(ctx, &EventFilter{
+		TokenAddress:  tokenAddress,
+		TargetAddress: targetAddress,
+		Since:         since,
+	}); err != nil {
+		return nil, util.WrapError(err, "unable to reconcile payment %d", identifier)
+	}
+
+	for _, event := range events {
+		if event.Identifier != identifier || event.EventName != EventPaymentSentSuccess {
+			continue
+		}
+
+		if event.Amount.Cmp(amount) != 0 {
+			return nil, fmt.Errorf("payment %d was already completed with amount %s instead of %s", identifier, event.Amount.String(), amount.String())
+		}
+
+		return &PaymentResult{
+			Identifier: identifier,
+			Event:      event,
+		}, nil
+	}
+
+	if transfers, err = payer.transferLister.ListAll(ctx); err != nil {
+		return nil, util.WrapError(err, "unable to reconcile payment %d", identifier)
+	}
+
+	for _, transfer := range transfers {
+		if transfer.PaymentIdentifier == identifier && transfer.TokenAddress == tokenAddress && transfer.Target == targetAddress {
+			return nil, ErrPaymentPending
+		}
+	}
+
+	return nil, nil
+}
+
+// isAmbiguous returns whether the node may have accepted a payment even though
+// initiating it returned the error. Client errors such as 402 Payment Required
+// and exceeded fee caps are definite, while timeouts and server errors are not.
+func isAmbiguous(err error) bool {
+	if _, ok := err.(*FeeCapError); ok {
+		return false
+	}
+
+	apiError, ok := util.AsAPIError(err)
+
+	if !ok {
+		return true
+	}
+
+	return apiError.StatusCode >= http.StatusInternalServerError
+}
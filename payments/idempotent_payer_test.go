@@ -0,0 +1,307 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/pending_transfers"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleIdempotentPayer() {
+	var (
+		paymentClient *Client
+		config        = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		tokenAddress  = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359") // DAI Stablecoin
+		targetAddress = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
+		result        *PaymentResult
+		err           error
+	)
+
+	paymentClient = NewClient(config, http.DefaultClient)
+
+	if result, err = paymentClient.Pay(context.Background(), "payout-2019-01-42", tokenAddress, targetAddress, util.NewAmount(1000), nil); err != nil {
+		panic(fmt.Sprintf("unable to pay out: %s", err.Error()))
+	}
+
+	fmt.Printf("payout completed with identifier %d\n", result.Identifier)
+}
+
+// fakePaymentNode records payments initiated through it and serves them as
+// payment events. Initiate fails with the scripted errors in order, optionally
+// after having recorded the payment or a pending transfer of it.
+type fakePaymentNode struct {
+	Lister
+
+	events        []*Event
+	transfers     *fakeTransferLister
+	errs          []error
+	recordOnError bool
+	pendOnError   bool
+	calls         int
+	filters       []*EventFilter
+}
+
+func (node *fakePaymentNode) Initiate(ctx context.Context, tokenAddress, targetAddress common.Address, amount *util.Amount, options *InitiateOptions) (*Payment, error) {
+	var (
+		err error
+	)
+
+	node.calls++
+
+	if len(node.errs) > 0 {
+		err, node.errs = node.errs[0], node.errs[1:]
+	}
+
+	if err == nil || node.recordOnError {
+		node.events = append(node.events, &Event{
+			EventName:    EventPaymentSentSuccess,
+			Amount:       amount,
+			Target:       targetAddress,
+			TokenAddress: tokenAddress,
+			Identifier:   options.Identifier,
+		})
+	}
+
+	if err != nil && node.pendOnError {
+		node.transfers.transfers = append(node.transfers.transfers, &pendingtransfers.Transfer{
+			PaymentIdentifier: options.Identifier,
+			TokenAddress:      tokenAddress,
+			Target:            targetAddress,
+		})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Payment{
+		TargetAddress: targetAddress,
+		TokenAddress:  tokenAddress,
+		Amount:        amount,
+		Identifier:    options.Identifier,
+	}, nil
+}
+
+func (node *fakePaymentNode) ListEvents(ctx context.Context, filter *EventFilter) ([]*Event, error) {
+	node.filters = append(node.filters, filter)
+
+	return node.events, nil
+}
+
+type fakeTransferLister struct {
+	pendingtransfers.Lister
+
+	transfers []*pendingtransfers.Transfer
+	err       error
+}
+
+func (lister *fakeTransferLister) ListAll(ctx context.Context) ([]*pendingtransfers.Transfer, error) {
+	return lister.transfers, lister.err
+}
+
+func TestIdempotentPayer(t *testing.T) {
+	var (
+		key            = "payout-42"
+		identifier     = PaymentIdentifier(key)
+		tokenAddress   = common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226")
+		partnerAddress = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
+		timeoutError   = errors.New("context deadline exceeded")
+		conflictError  = &util.APIError{StatusCode: http.StatusConflict, Method: "POST", URL: "http://localhost:5001/api/v1/payments"}
+	)
+
+	type testcase struct {
+		name          string
+		node          *fakePaymentNode
+		expectedCalls int
+		expectPayment bool
+		expectEvent   bool
+		expectedError error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:          "new payment is sent once",
+			node:          &fakePaymentNode{},
+			expectedCalls: 1,
+			expectPayment: true,
+		},
+		testcase{
+			name: "completed payment is not sent again",
+			node: &fakePaymentNode{
+				events: []*Event{
+					&Event{EventName: EventPaymentSentSuccess, Identifier: identifier, Amount: util.NewAmount(200)},
+				},
+			},
+			expectedCalls: 0,
+			expectEvent:   true,
+		},
+		testcase{
+			name: "completed payment with a different amount is an error",
+			node: &fakePaymentNode{
+				events: []*Event{
+					&Event{EventName: EventPaymentSentSuccess, Identifier: identifier, Amount: util.NewAmount(300)},
+				},
+			},
+			expectedCalls: 0,
+			expectedError: fmt.Errorf("payment %d was already completed with amount 300 instead of 200", identifier),
+		},
+		testcase{
+			name: "pending payment is not sent again",
+			node: &fakePaymentNode{
+				transfers: &fakeTransferLister{
+					transfers: []*pendingtransfers.Transfer{
+						&pendingtransfers.Transfer{PaymentIdentifier: identifier, TokenAddress: tokenAddress, Target: partnerAddress},
+					},
+				},
+			},
+			expectedCalls: 0,
+			expectedError: ErrPaymentPending,
+		},
+		testcase{
+			name: "failed payment is sent again",
+			node: &fakePaymentNode{
+				events: []*Event{
+					&Event{EventName: EventPaymentSentFailed, Identifier: identifier, Amount: util.NewAmount(200)},
+				},
+			},
+			expectedCalls: 1,
+			expectPayment: true,
+		},
+		testcase{
+			name: "timed out payment that completed is reconciled",
+			node: &fakePaymentNode{
+				errs:          []error{timeoutError},
+				recordOnError: true,
+			},
+			expectedCalls: 1,
+			expectEvent:   true,
+		},
+		testcase{
+			name: "timed out payment that never arrived is sent again",
+			node: &fakePaymentNode{
+				errs: []error{timeoutError},
+			},
+			expectedCalls: 2,
+			expectPayment: true,
+		},
+		testcase{
+			name: "timed out payment is given up after max attempts",
+			node: &fakePaymentNode{
+				errs: []error{timeoutError, timeoutError, timeoutError},
+			},
+			expectedCalls: 3,
+			expectedError: timeoutError,
+		},
+		testcase{
+			name: "definite failure is not sent again",
+			node: &fakePaymentNode{
+				errs: []error{&util.APIError{StatusCode: http.StatusPaymentRequired, Method: "POST", URL: "http://localhost:5001/api/v1/payments"}},
+			},
+			expectedCalls: 1,
+			expectedError: &util.APIError{StatusCode: http.StatusPaymentRequired, Method: "POST", URL: "http://localhost:5001/api/v1/payments"},
+		},
+		testcase{
+			name: "conflict without a trace of the payment is not sent again",
+			node: &fakePaymentNode{
+				errs: []error{conflictError},
+			},
+			expectedCalls: 1,
+			expectedError: conflictError,
+		},
+		testcase{
+			name: "conflict of a payment in flight is pending",
+			node: &fakePaymentNode{
+				errs:        []error{conflictError},
+				pendOnError: true,
+			},
+			expectedCalls: 1,
+			expectedError: ErrPaymentPending,
+		},
+		testcase{
+			name: "conflict of a completed payment is reconciled",
+			node: &fakePaymentNode{
+				errs:          []error{conflictError},
+				recordOnError: true,
+			},
+			expectedCalls: 1,
+			expectEvent:   true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.node.transfers == nil {
+				tc.node.transfers = &fakeTransferLister{}
+			}
+
+			var (
+				payer = NewIdempotentPayer(tc.node, tc.node, tc.node.transfers, &IdempotentPayerOptions{
+					MaxAttempts:     3,
+					RetryInterval:   time.Millisecond,
+					ReconcileWindow: time.Hour,
+				})
+				ctx   = context.Background()
+				start = time.Now()
+			)
+
+			result, err := payer.Pay(ctx, key, tokenAddress, partnerAddress, util.NewAmount(200), nil)
+
+			assert.Equal(t, tc.expectedCalls, tc.node.calls)
+
+			// only the events within the reconcile window are listed
+			for _, filter := range tc.node.filters {
+				assert.Equal(t, partnerAddress, filter.TargetAddress)
+				assert.False(t, filter.Since.Before(start.Add(-time.Hour)))
+				assert.False(t, filter.Since.After(start.Add(-time.Hour+time.Second)))
+			}
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, identifier, result.Identifier)
+			assert.Equal(t, tc.expectPayment, result.Payment != nil)
+			assert.Equal(t, tc.expectEvent, result.Event != nil)
+		})
+	}
+}
+
+func TestIdempotentPayerReconcileError(t *testing.T) {
+	var (
+		node = &fakePaymentNode{
+			transfers: &fakeTransferLister{
+				err: &util.APIError{StatusCode: http.StatusServiceUnavailable, Method: "GET", URL: "http://localhost:5001/api/v1/pending_transfers"},
+			},
+		}
+		payer = NewIdempotentPayer(node, node, node.transfers, nil)
+	)
+
+	_, err := payer.Pay(context.Background(), "payout-42", common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226"), common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9"), util.NewAmount(200), nil)
+
+	// the error of the node is kept so that it can still be told apart
+	apiError, ok := util.AsAPIError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusServiceUnavailable, apiError.StatusCode)
+	assert.EqualError(t, err, fmt.Sprintf("unable to reconcile payment %d: GET http://localhost:5001/api/v1/pending_transfers: 503 Service Unavailable", PaymentIdentifier("payout-42")))
+	assert.Equal(t, 0, node.calls)
+}
+
+func TestPaymentIdentifier(t *testing.T) {
+	assert.Equal(t, PaymentIdentifier("payout-42"), PaymentIdentifier("payout-42"))
+	assert.NotEqual(t, PaymentIdentifier("payout-42"), PaymentIdentifier("payout-43"))
+	assert.True(t, PaymentIdentifier("") > 0)
+}
@@ -2,10 +2,9 @@ package payments
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"crypto/sha256"
+	"errors"
 	"net/http"
-	"net/url"
 
 	"github.com/cpurta/go-raiden-client/config"
 	"github.com/cpurta/go-raiden-client/util"
@@ -13,13 +12,36 @@ import (
 )
 
 type initiatePaymentRequest struct {
-	Amount int64 `json:"amount"`
+	Amount      *util.Amount `json:"amount"`
+	Identifier  uint64       `json:"identifier,omitempty"`
+	Secret      *common.Hash `json:"secret,omitempty"`
+	SecretHash  *common.Hash `json:"secret_hash,omitempty"`
+	LockTimeout int64        `json:"lock_timeout,omitempty"`
 }
 
+// InitiateOptions holds the optional parameters of a payment. Any zero valued
+// field is left out of the request so that the Raiden node picks its default.
+type InitiateOptions struct {
+	// Identifier is the payment identifier used to match the payment to an
+	// invoice or order. The node generates one if left empty.
+	Identifier uint64
+	// Secret is the secret used to unlock the payment. Supplying a secret allows
+	// the payment to be part of a hash-locked atomic swap.
+	Secret common.Hash
+	// SecretHash is the sha256 hash of the secret. If only the secret hash is
+	// given the target has to learn the secret out of band.
+	SecretHash common.Hash
+	// LockTimeout is the number of blocks the payment lock is valid for.
+	LockTimeout int64
+}
+
+// Initiator is an interface to initiate a payment of a token to a target address.
 type Initiator interface {
-	Initiate(ctx context.Context, tokenAddress, targetAddress common.Address, amount int64) (*Payment, error)
+	Initiate(ctx context.Context, tokenAddress, targetAddress common.Address, amount *util.Amount, options *InitiateOptions) (*Payment, error)
 }
 
+// NewInitiator will create a default initiator that is able to make payments
+// from a Raiden node.
 func NewInitiator(config *config.Config, httpClient *http.Client) Initiator {
 	return &defaultInitiator{
 		baseClient: &util.BaseClient{
@@ -33,49 +55,62 @@ type defaultInitiator struct {
 	baseClient *util.BaseClient
 }
 
-func (initiator *defaultInitiator) Initiate(ctx context.Context, tokenAddress, targetAddress common.Address, amount int64) (*Payment, error) {
+// Initiate will pay the given amount of a token to the target address. Options
+// may be nil in which case the node will generate the identifier and secret. A
+// payment is never retried by the config's retry policy, as a failed attempt may
+// still have been made by the node. Use an IdempotentPayer to retry payments.
+func (initiator *defaultInitiator) Initiate(ctx context.Context, tokenAddress, targetAddress common.Address, amount *util.Amount, options *InitiateOptions) (*Payment, error) {
 	var (
-		err     error
-		payment *Payment
-
-		requestURL *url.URL
-		request    *http.Request
-		response   *http.Response
+		err            error
+		payment        *Payment
+		paymentRequest *initiatePaymentRequest
 	)
 
-	if requestURL, err = initiator.getRequestURL(tokenAddress, targetAddress); err != nil {
+	if paymentRequest, err = newInitiatePaymentRequest(amount, options); err != nil {
 		return nil, err
 	}
 
-	if request, err = http.NewRequest("POST", requestURL.String(), nil); err != nil {
+	if err = initiator.baseClient.Do(util.WithoutIdempotent(ctx), "POST", []string{"payments", tokenAddress.Hex(), targetAddress.Hex()}, paymentRequest, &payment); err != nil {
 		return nil, err
 	}
 
-	request = request.WithContext(ctx)
+	return payment, nil
+}
+
+func newInitiatePaymentRequest(amount *util.Amount, options *InitiateOptions) (*initiatePaymentRequest, error) {
+	var (
+		zeroHash       = common.Hash{}
+		paymentRequest = &initiatePaymentRequest{
+			Amount: amount,
+		}
+	)
 
-	if response, err = initiator.baseClient.HTTPClient.Do(request); err != nil {
-		return nil, err
+	if options == nil {
+		return paymentRequest, nil
 	}
 
-	defer response.Body.Close()
-
-	if err = json.NewDecoder(response.Body).Decode(&payment); err != nil {
-		return nil, err
+	if options.LockTimeout < 0 {
+		return nil, errors.New("lock timeout must not be negative")
 	}
 
-	return payment, nil
-}
+	paymentRequest.Identifier = options.Identifier
+	paymentRequest.LockTimeout = options.LockTimeout
 
-func (initiator *defaultInitiator) getRequestURL(tokenAddress, targetAddress common.Address) (*url.URL, error) {
-	var (
-		err        error
-		endpoint   = fmt.Sprintf("%s/api/%s/payments/%s/%s", initiator.baseClient.Config.Host, initiator.baseClient.Config.APIVersion, tokenAddress.Hex(), targetAddress.Hex())
-		requestURL *url.URL
-	)
+	if options.Secret != zeroHash {
+		secret := options.Secret
+		paymentRequest.Secret = &secret
+	}
 
-	if requestURL, err = url.Parse(endpoint); err != nil {
-		return nil, err
+	if options.SecretHash != zeroHash {
+		secretHash := options.SecretHash
+		paymentRequest.SecretHash = &secretHash
+	}
+
+	if paymentRequest.Secret != nil && paymentRequest.SecretHash != nil {
+		if common.Hash(sha256.Sum256(options.Secret.Bytes())) != options.SecretHash {
+			return nil, errors.New("secret hash is not the sha256 hash of the secret")
+		}
 	}
 
-	return requestURL, nil
+	return paymentRequest, nil
 }
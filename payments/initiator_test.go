@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"testing"
 
 	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -24,14 +26,17 @@ func ExampleInitiator() {
 		}
 		tokenAddress  = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359") // DAI Stablecoin
 		targetAddress = common.HexToAddress("")
-		payment       *Payment
-		amount        = int64(1000)
-		err           error
+		amount        = util.NewAmount(1000)
+		options       = &InitiateOptions{
+			Identifier: 1337,
+		}
+		payment *Payment
+		err     error
 	)
 
 	paymentClient = NewClient(config, http.DefaultClient)
 
-	if payment, err = paymentClient.Initiate(context.Background(), tokenAddress, targetAddress, amount); err != nil {
+	if payment, err = paymentClient.Initiate(context.Background(), tokenAddress, targetAddress, amount, options); err != nil {
 		panic(fmt.Sprintf("unable to initiate payment: %s", err.Error()))
 	}
 
@@ -54,6 +59,7 @@ func TestInitiator(t *testing.T) {
 	type testcase struct {
 		name            string
 		prepHTTPMock    func()
+		options         *InitiateOptions
 		expectedPayment *Payment
 		expectedError   error
 	}
@@ -76,10 +82,79 @@ func TestInitiator(t *testing.T) {
 				InitiatorAddress: common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"),
 				TargetAddress:    common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9"),
 				TokenAddress:     common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226"),
-				Amount:           int64(200),
-				Identifier:       int64(42),
+				Amount:           util.NewAmount(200),
+				Identifier:       uint64(42),
+			},
+		},
+		testcase{
+			name: "identifier generated by the node above the int64 range",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"POST",
+					"http://localhost:5001/api/v1/payments/0x2a65Aca4D5fC5B5C859090a6c34d164135398226/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						`{"initiator_address":"0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8","target_address":"0x61C808D82A3Ac53231750daDc13c777b59310bD9","token_address":"0x2a65Aca4D5fC5B5C859090a6c34d164135398226","amount":200,"identifier":18446744073709551615}`,
+					),
+				)
+			},
+			expectedError: nil,
+			expectedPayment: &Payment{
+				InitiatorAddress: common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"),
+				TargetAddress:    common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9"),
+				TokenAddress:     common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226"),
+				Amount:           util.NewAmount(200),
+				Identifier:       uint64(18446744073709551615),
+			},
+		},
+		testcase{
+			name: "successfully initiated a funds transfer with options",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"POST",
+					"http://localhost:5001/api/v1/payments/0x2a65Aca4D5fC5B5C859090a6c34d164135398226/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+					func(request *http.Request) (*http.Response, error) {
+						body, _ := ioutil.ReadAll(request.Body)
+
+						if string(body) != `{"amount":200,"identifier":42,"secret":"0x2ff886d47b156de00d4cad5d8c332706692b5b572adfe35e6d2f65e92906806e","secret_hash":"0x2eb110722be384d1af597f5e58de7a7b0e7bbf4c475c0f6511246d162d2aa86a","lock_timeout":50}` {
+							return httpmock.NewStringResponse(http.StatusBadRequest, `{"errors":"unexpected request body"}`), nil
+						}
+
+						return httpmock.NewStringResponse(
+							http.StatusOK,
+							`{"initiator_address":"0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8","target_address":"0x61C808D82A3Ac53231750daDc13c777b59310bD9","token_address":"0x2a65Aca4D5fC5B5C859090a6c34d164135398226","amount":200,"identifier":42,"secret":"0x2ff886d47b156de00d4cad5d8c332706692b5b572adfe35e6d2f65e92906806e","secret_hash":"0x2eb110722be384d1af597f5e58de7a7b0e7bbf4c475c0f6511246d162d2aa86a","lock_timeout":50}`,
+						), nil
+					},
+				)
+			},
+			options: &InitiateOptions{
+				Identifier:  42,
+				Secret:      common.HexToHash("0x2ff886d47b156de00d4cad5d8c332706692b5b572adfe35e6d2f65e92906806e"),
+				SecretHash:  common.HexToHash("0x2eb110722be384d1af597f5e58de7a7b0e7bbf4c475c0f6511246d162d2aa86a"),
+				LockTimeout: 50,
+			},
+			expectedError: nil,
+			expectedPayment: &Payment{
+				InitiatorAddress: common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"),
+				TargetAddress:    common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9"),
+				TokenAddress:     common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226"),
+				Amount:           util.NewAmount(200),
+				Identifier:       uint64(42),
+				Secret:           common.HexToHash("0x2ff886d47b156de00d4cad5d8c332706692b5b572adfe35e6d2f65e92906806e"),
+				SecretHash:       common.HexToHash("0x2eb110722be384d1af597f5e58de7a7b0e7bbf4c475c0f6511246d162d2aa86a"),
+				LockTimeout:      int64(50),
 			},
 		},
+		testcase{
+			name:         "mismatched secret hash",
+			prepHTTPMock: func() {},
+			options: &InitiateOptions{
+				Secret:     common.HexToHash("0x2ff886d47b156de00d4cad5d8c332706692b5b572adfe35e6d2f65e92906806e"),
+				SecretHash: common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000001"),
+			},
+			expectedError:   errors.New("secret hash is not the sha256 hash of the secret"),
+			expectedPayment: nil,
+		},
 		testcase{
 			name: "unexpected 500 response",
 			prepHTTPMock: func() {
@@ -92,7 +167,7 @@ func TestInitiator(t *testing.T) {
 					),
 				)
 			},
-			expectedError:   errors.New("EOF"),
+			expectedError:   &util.APIError{StatusCode: http.StatusInternalServerError, Method: "POST", URL: "http://localhost:5001/api/v1/payments/0x2a65Aca4D5fC5B5C859090a6c34d164135398226/0x61C808D82A3Ac53231750daDc13c777b59310bD9"},
 			expectedPayment: nil,
 		},
 		testcase{
@@ -123,7 +198,7 @@ func TestInitiator(t *testing.T) {
 
 			// test list all
 
-			payment, err = initiator.Initiate(ctx, tokenAddress, partnerAddress, int64(200))
+			payment, err = initiator.Initiate(ctx, tokenAddress, partnerAddress, util.NewAmount(200), tc.options)
 
 			if tc.expectedError != nil {
 				assert.EqualError(t, err, tc.expectedError.Error())
@@ -135,3 +210,65 @@ func TestInitiator(t *testing.T) {
 		})
 	}
 }
+
+func TestInitiatorRetry(t *testing.T) {
+	var (
+		config = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+			RetryPolicy: &config.RetryPolicy{
+				MaxAttempts:          2,
+				RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+			},
+		}
+	)
+
+	type testcase struct {
+		name          string
+		options       *InitiateOptions
+		expectedCalls int
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:          "payment without identifier is not retried",
+			options:       nil,
+			expectedCalls: 1,
+		},
+		testcase{
+			name:          "payment with identifier is not retried",
+			options:       &InitiateOptions{Identifier: 42},
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				calls          = 0
+				initiator      = NewInitiator(config, http.DefaultClient)
+				ctx            = util.WithIdempotent(context.Background())
+				tokenAddress   = common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226")
+				partnerAddress = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			httpmock.RegisterResponder(
+				"POST",
+				"http://localhost:5001/api/v1/payments/0x2a65Aca4D5fC5B5C859090a6c34d164135398226/0x61C808D82A3Ac53231750daDc13c777b59310bD9",
+				func(request *http.Request) (*http.Response, error) {
+					calls++
+
+					return httpmock.NewStringResponse(http.StatusServiceUnavailable, ``), nil
+				},
+			)
+
+			_, err := initiator.Initiate(ctx, tokenAddress, partnerAddress, util.NewAmount(200), tc.options)
+
+			assert.Error(t, err)
+			assert.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
@@ -2,10 +2,10 @@ package payments
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"errors"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/cpurta/go-raiden-client/config"
@@ -13,10 +13,41 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+var (
+	zeroAddress = common.Address{}
+)
+
+// EventFilter narrows down the payment events returned by a Lister. Any zero
+// valued field is ignored.
+type EventFilter struct {
+	// TokenAddress limits the events to a single token network.
+	TokenAddress common.Address
+	// TargetAddress limits the events to payments with a single partner. It
+	// requires the TokenAddress to be set.
+	TargetAddress common.Address
+	// Limit is the maximum number of events returned.
+	Limit int
+	// Offset is the number of events skipped.
+	Offset int
+	// Since only includes events logged at or after the given time.
+	Since time.Time
+	// Until only includes events logged before the given time.
+	Until time.Time
+}
+
+// Lister is an interface to list the payment events of a Raiden node, either for
+// a single partner, a single token network or across all token networks.
 type Lister interface {
 	List(ctx context.Context, tokenAddress, targetAddress common.Address) ([]*Event, error)
+	ListToken(ctx context.Context, tokenAddress common.Address) ([]*Event, error)
+	ListAll(ctx context.Context) ([]*Event, error)
+	ListEvents(ctx context.Context, filter *EventFilter) ([]*Event, error)
 }
 
+var _ Lister = &defaultLister{}
+
+// NewLister will create a default lister that is able to list the payment events
+// of a Raiden node.
 func NewLister(config *config.Config, httpClient *http.Client) Lister {
 	return &defaultLister{
 		baseClient: &util.BaseClient{
@@ -30,68 +61,144 @@ type defaultLister struct {
 	baseClient *util.BaseClient
 }
 
+// List will list the payment events between the node and the target address for
+// the given token.
 func (lister *defaultLister) List(ctx context.Context, tokenAddress, targetAddress common.Address) ([]*Event, error) {
+	return lister.ListEvents(ctx, &EventFilter{
+		TokenAddress:  tokenAddress,
+		TargetAddress: targetAddress,
+	})
+}
+
+// ListToken will list the payment events of the node for the given token.
+func (lister *defaultLister) ListToken(ctx context.Context, tokenAddress common.Address) ([]*Event, error) {
+	return lister.ListEvents(ctx, &EventFilter{
+		TokenAddress: tokenAddress,
+	})
+}
+
+// ListAll will list the payment events of the node across all tokens.
+func (lister *defaultLister) ListAll(ctx context.Context) ([]*Event, error) {
+	return lister.ListEvents(ctx, &EventFilter{})
+}
+
+// ListEvents will list the payment events matching the given filter. Limit and
+// Offset page through the events within the time range. The node can only page
+// through all events, so if Since or Until is set the node's whole event history
+// is fetched and paged after the time range is applied.
+func (lister *defaultLister) ListEvents(ctx context.Context, filter *EventFilter) ([]*Event, error) {
 	var (
 		err           error
+		requestURL    *url.URL
 		events        = make([]*event, 0)
 		paymentEvents = make([]*Event, 0)
-
-		requestURL *url.URL
-		request    *http.Request
-		response   *http.Response
 	)
 
-	if requestURL, err = lister.getRequestURL(tokenAddress, targetAddress); err != nil {
-		return nil, err
+	if filter == nil {
+		filter = &EventFilter{}
 	}
 
-	if request, err = http.NewRequest("GET", requestURL.String(), nil); err != nil {
-		return nil, err
+	// the limit and offset of a time range are applied here, so they are checked
+	// before they are left out of the request to the node
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, errors.New("limit and offset must not be negative")
 	}
 
-	request = request.WithContext(ctx)
+	nodeFilter := *filter
 
-	if response, err = lister.baseClient.HTTPClient.Do(request); err != nil {
-		return nil, err
+	if hasTimeRange(filter) {
+		nodeFilter.Limit = 0
+		nodeFilter.Offset = 0
 	}
 
-	defer response.Body.Close()
+	if requestURL, err = lister.getRequestURL(&nodeFilter); err != nil {
+		return nil, err
+	}
 
-	if err = json.NewDecoder(response.Body).Decode(&events); err != nil {
+	if err = lister.baseClient.DoURL(ctx, "GET", requestURL, nil, &events); err != nil {
 		return nil, err
 	}
 
 	for _, event := range events {
 		var (
-			logTime time.Time
+			paymentEvent *Event
 		)
-		if logTime, err = time.Parse(time.RFC3339, event.LogTime); err != nil {
+
+		if paymentEvent, err = event.toEvent(); err != nil {
+			return nil, err
+		}
+
+		if !filter.Since.IsZero() && paymentEvent.LogTime.Before(filter.Since) {
+			continue
+		}
+
+		if !filter.Until.IsZero() && !paymentEvent.LogTime.Before(filter.Until) {
 			continue
 		}
 
-		paymentEvents = append(paymentEvents, &Event{
-			EventName:  event.EventName,
-			Amount:     event.Amount,
-			Initiator:  common.HexToAddress(event.Initiator),
-			Target:     common.HexToAddress(event.Target),
-			Identifier: event.Identifier,
-			LogTime:    logTime,
-		})
+		paymentEvents = append(paymentEvents, paymentEvent)
+	}
+
+	if hasTimeRange(filter) {
+		paymentEvents = page(paymentEvents, filter.Offset, filter.Limit)
 	}
 
 	return paymentEvents, nil
 }
 
-func (lister *defaultLister) getRequestURL(tokenAddress, targetAddress common.Address) (*url.URL, error) {
+func hasTimeRange(filter *EventFilter) bool {
+	return !filter.Since.IsZero() || !filter.Until.IsZero()
+}
+
+// page returns at most limit events after skipping offset events. A limit of zero
+// returns all remaining events.
+func page(events []*Event, offset, limit int) []*Event {
+	if offset >= len(events) {
+		return make([]*Event, 0)
+	}
+
+	events = events[offset:]
+
+	if limit > 0 && limit < len(events) {
+		events = events[:limit]
+	}
+
+	return events
+}
+
+func (lister *defaultLister) getRequestURL(filter *EventFilter) (*url.URL, error) {
 	var (
-		err        error
-		endpoint   = fmt.Sprintf("%s/api/%s/payments/%s/%s", lister.baseClient.Config.Host, lister.baseClient.Config.APIVersion, tokenAddress.Hex(), targetAddress.Hex())
-		requestURL *url.URL
+		err          error
+		requestURL   *url.URL
+		query        = url.Values{}
+		pathSegments = []string{"payments"}
 	)
 
-	if requestURL, err = url.Parse(endpoint); err != nil {
+	if filter.TargetAddress != zeroAddress && filter.TokenAddress == zeroAddress {
+		return nil, errors.New("a token address is required to list payments with a target address")
+	}
+
+	if filter.TokenAddress != zeroAddress {
+		pathSegments = append(pathSegments, filter.TokenAddress.Hex())
+	}
+
+	if filter.TargetAddress != zeroAddress {
+		pathSegments = append(pathSegments, filter.TargetAddress.Hex())
+	}
+
+	if requestURL, err = lister.baseClient.URL(pathSegments...); err != nil {
 		return nil, err
 	}
 
+	if filter.Limit > 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+
+	if filter.Offset > 0 {
+		query.Set("offset", strconv.Itoa(filter.Offset))
+	}
+
+	requestURL.RawQuery = query.Encode()
+
 	return requestURL, nil
 }
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -79,24 +80,24 @@ func TestLister(t *testing.T) {
 			expectedError: nil,
 			expectedEvents: []*Event{
 				&Event{
-					EventName:  "EventPaymentReceivedSuccess",
-					Amount:     int64(5),
+					EventName:  EventPaymentReceivedSuccess,
+					Amount:     util.NewAmount(5),
 					Initiator:  common.HexToAddress("0x82641569b2062B545431cF6D7F0A418582865ba7"),
-					Identifier: int64(1),
+					Identifier: uint64(1),
 					LogTime:    time1,
 				},
 				&Event{
-					EventName:  "EventPaymentSentSuccess",
-					Amount:     int64(35),
+					EventName:  EventPaymentSentSuccess,
+					Amount:     util.NewAmount(35),
 					Target:     common.HexToAddress("0x82641569b2062B545431cF6D7F0A418582865ba7"),
-					Identifier: int64(2),
+					Identifier: uint64(2),
 					LogTime:    time2,
 				},
 				&Event{
-					EventName:  "EventPaymentSentSuccess",
-					Amount:     int64(20),
+					EventName:  EventPaymentSentSuccess,
+					Amount:     util.NewAmount(20),
 					Target:     common.HexToAddress("0x82641569b2062B545431cF6D7F0A418582865ba7"),
-					Identifier: int64(3),
+					Identifier: uint64(3),
 					LogTime:    time3,
 				},
 			},
@@ -113,7 +114,7 @@ func TestLister(t *testing.T) {
 					),
 				)
 			},
-			expectedError:  errors.New("EOF"),
+			expectedError:  &util.APIError{StatusCode: http.StatusInternalServerError, Method: "GET", URL: "http://localhost:5001/api/v1/payments/0x0f114A1E9Db192502E7856309cc899952b3db1ED/0x82641569b2062B545431cF6D7F0A418582865ba7"},
 			expectedEvents: nil,
 		},
 		testcase{
@@ -156,3 +157,224 @@ func TestLister(t *testing.T) {
 		})
 	}
 }
+
+func TestListerListEvents(t *testing.T) {
+	var (
+		config = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+
+		time1, _ = time.Parse(time.RFC3339, "2018-10-30T07:03:52.193Z")
+		time2, _ = time.Parse(time.RFC3339, "2018-10-30T07:04:22.293Z")
+		time3, _ = time.Parse(time.RFC3339, "2018-10-30T07:10:13.122Z")
+	)
+
+	// registerQuery only responds to requests with exactly the given query, as
+	// httpmock falls back to responders without a query otherwise
+	registerQuery := func(query, body string) {
+		httpmock.RegisterResponder("GET", "http://localhost:5001/api/v1/payments/0x0f114A1E9Db192502E7856309cc899952b3db1ED", func(request *http.Request) (*http.Response, error) {
+			if request.URL.RawQuery != query {
+				return httpmock.NewStringResponse(http.StatusBadRequest, `{"errors":"unexpected query"}`), nil
+			}
+
+			return httpmock.NewStringResponse(http.StatusOK, body), nil
+		})
+	}
+
+	type testcase struct {
+		name           string
+		filter         *EventFilter
+		prepHTTPMock   func()
+		expectedEvents []*Event
+		expectedError  error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:   "successfully lists events across all tokens",
+			filter: &EventFilter{},
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/payments",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						`[{"event":"EventPaymentReceivedSuccess","amount":5,"initiator":"0x82641569b2062B545431cF6D7F0A418582865ba7","token_address":"0x0f114A1E9Db192502E7856309cc899952b3db1ED","identifier":1,"log_time":"2018-10-30T07:03:52.193"},{"event":"EventPaymentSentFailed","target":"0x82641569b2062B545431cF6D7F0A418582865ba7","token_address":"0x0f114A1E9Db192502E7856309cc899952b3db1ED","identifier":2,"reason":"there is no route available","log_time":"2018-10-30T07:04:22.293Z"}]`,
+					),
+				)
+			},
+			expectedError: nil,
+			expectedEvents: []*Event{
+				&Event{
+					EventName:    EventPaymentReceivedSuccess,
+					Amount:       util.NewAmount(5),
+					Initiator:    common.HexToAddress("0x82641569b2062B545431cF6D7F0A418582865ba7"),
+					TokenAddress: common.HexToAddress("0x0f114A1E9Db192502E7856309cc899952b3db1ED"),
+					Identifier:   uint64(1),
+					LogTime:      time1,
+				},
+				&Event{
+					EventName:    EventPaymentSentFailed,
+					Target:       common.HexToAddress("0x82641569b2062B545431cF6D7F0A418582865ba7"),
+					TokenAddress: common.HexToAddress("0x0f114A1E9Db192502E7856309cc899952b3db1ED"),
+					Identifier:   uint64(2),
+					Reason:       "there is no route available",
+					LogTime:      time2,
+				},
+			},
+		},
+		testcase{
+			name: "successfully paginates events on the node",
+			filter: &EventFilter{
+				TokenAddress: common.HexToAddress("0x0f114A1E9Db192502E7856309cc899952b3db1ED"),
+				Limit:        1,
+				Offset:       2,
+			},
+			prepHTTPMock: func() {
+				registerQuery("limit=1&offset=2", `[{"event":"EventPaymentSentSuccess","amount":20,"target":"0x82641569b2062B545431cF6D7F0A418582865ba7","identifier":3,"log_time":"2018-10-30T07:10:13.122Z"}]`)
+			},
+			expectedError: nil,
+			expectedEvents: []*Event{
+				&Event{
+					EventName:  EventPaymentSentSuccess,
+					Amount:     util.NewAmount(20),
+					Target:     common.HexToAddress("0x82641569b2062B545431cF6D7F0A418582865ba7"),
+					Identifier: uint64(3),
+					LogTime:    time3,
+				},
+			},
+		},
+		testcase{
+			name: "successfully filters events by time",
+			filter: &EventFilter{
+				TokenAddress: common.HexToAddress("0x0f114A1E9Db192502E7856309cc899952b3db1ED"),
+				Since:        time2,
+				Until:        time3,
+			},
+			prepHTTPMock: func() {
+				registerQuery("", `[{"event":"EventPaymentReceivedSuccess","amount":5,"initiator":"0x82641569b2062B545431cF6D7F0A418582865ba7","identifier":1,"log_time":"2018-10-30T07:03:52.193Z"},{"event":"EventPaymentSentSuccess","amount":35,"target":"0x82641569b2062B545431cF6D7F0A418582865ba7","identifier":2,"log_time":"2018-10-30T07:04:22.293Z"},{"event":"EventPaymentSentSuccess","amount":20,"target":"0x82641569b2062B545431cF6D7F0A418582865ba7","identifier":3,"log_time":"2018-10-30T07:10:13.122Z"}]`)
+			},
+			expectedError: nil,
+			expectedEvents: []*Event{
+				&Event{
+					EventName:  EventPaymentSentSuccess,
+					Amount:     util.NewAmount(35),
+					Target:     common.HexToAddress("0x82641569b2062B545431cF6D7F0A418582865ba7"),
+					Identifier: uint64(2),
+					LogTime:    time2,
+				},
+			},
+		},
+		testcase{
+			name: "successfully paginates events within the time range",
+			filter: &EventFilter{
+				TokenAddress: common.HexToAddress("0x0f114A1E9Db192502E7856309cc899952b3db1ED"),
+				Limit:        1,
+				Offset:       1,
+				Since:        time2,
+			},
+			prepHTTPMock: func() {
+				// paging on the node would skip the first event and return the
+				// second, which is the first one within the time range
+				registerQuery("", `[{"event":"EventPaymentReceivedSuccess","amount":5,"initiator":"0x82641569b2062B545431cF6D7F0A418582865ba7","identifier":1,"log_time":"2018-10-30T07:03:52.193Z"},{"event":"EventPaymentSentSuccess","amount":35,"target":"0x82641569b2062B545431cF6D7F0A418582865ba7","identifier":2,"log_time":"2018-10-30T07:04:22.293Z"},{"event":"EventPaymentSentSuccess","amount":20,"target":"0x82641569b2062B545431cF6D7F0A418582865ba7","identifier":3,"log_time":"2018-10-30T07:10:13.122Z"}]`)
+			},
+			expectedError: nil,
+			expectedEvents: []*Event{
+				&Event{
+					EventName:  EventPaymentSentSuccess,
+					Amount:     util.NewAmount(20),
+					Target:     common.HexToAddress("0x82641569b2062B545431cF6D7F0A418582865ba7"),
+					Identifier: uint64(3),
+					LogTime:    time3,
+				},
+			},
+		},
+		testcase{
+			name: "offset beyond the time range",
+			filter: &EventFilter{
+				TokenAddress: common.HexToAddress("0x0f114A1E9Db192502E7856309cc899952b3db1ED"),
+				Offset:       2,
+				Since:        time2,
+			},
+			prepHTTPMock: func() {
+				registerQuery("", `[{"event":"EventPaymentReceivedSuccess","amount":5,"initiator":"0x82641569b2062B545431cF6D7F0A418582865ba7","identifier":1,"log_time":"2018-10-30T07:03:52.193Z"},{"event":"EventPaymentSentSuccess","amount":35,"target":"0x82641569b2062B545431cF6D7F0A418582865ba7","identifier":2,"log_time":"2018-10-30T07:04:22.293Z"},{"event":"EventPaymentSentSuccess","amount":20,"target":"0x82641569b2062B545431cF6D7F0A418582865ba7","identifier":3,"log_time":"2018-10-30T07:10:13.122Z"}]`)
+			},
+			expectedError:  nil,
+			expectedEvents: []*Event{},
+		},
+		testcase{
+			name: "negative offset within a time range",
+			filter: &EventFilter{
+				TokenAddress: common.HexToAddress("0x0f114A1E9Db192502E7856309cc899952b3db1ED"),
+				Offset:       -1,
+				Since:        time2,
+			},
+			prepHTTPMock: func() {
+				registerQuery("", `[{"event":"EventPaymentSentSuccess","amount":35,"target":"0x82641569b2062B545431cF6D7F0A418582865ba7","identifier":2,"log_time":"2018-10-30T07:04:22.293Z"}]`)
+			},
+			expectedError:  errors.New("limit and offset must not be negative"),
+			expectedEvents: nil,
+		},
+		testcase{
+			name: "negative limit",
+			filter: &EventFilter{
+				Limit: -1,
+			},
+			prepHTTPMock:   func() {},
+			expectedError:  errors.New("limit and offset must not be negative"),
+			expectedEvents: nil,
+		},
+		testcase{
+			name: "target address without token address",
+			filter: &EventFilter{
+				TargetAddress: common.HexToAddress("0x82641569b2062B545431cF6D7F0A418582865ba7"),
+			},
+			prepHTTPMock:   func() {},
+			expectedError:  errors.New("a token address is required to list payments with a target address"),
+			expectedEvents: nil,
+		},
+		testcase{
+			name:   "invalid event log time",
+			filter: &EventFilter{},
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/payments",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						`[{"event":"EventPaymentReceivedSuccess","amount":5,"initiator":"0x82641569b2062B545431cF6D7F0A418582865ba7","identifier":1,"log_time":"yesterday"}]`,
+					),
+				)
+			},
+			expectedError:  errors.New(`unable to parse payment event log time: "yesterday"`),
+			expectedEvents: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				err    error
+				events []*Event
+				lister = NewLister(config, http.DefaultClient)
+				ctx    = context.Background()
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			tc.prepHTTPMock()
+
+			events, err = lister.ListEvents(ctx, tc.filter)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedEvents, events)
+		})
+	}
+}
@@ -1,11 +1,21 @@
 package payments
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
 
+// Payment is the result of an initiated payment. The secret and secret hash are
+// either the ones given in the InitiateOptions or the ones generated by the node.
+// The node does not include a timestamp in its response to a payment; the time a
+// payment completed is the LogTime of its Event, found by its Identifier.
 type Payment struct {
 	InitiatorAddress common.Address `json:"initiator_address"`
 	TargetAddress    common.Address `json:"target_address"`
 	TokenAddress     common.Address `json:"token_address"`
-	Amount           int64          `json:"amount"`
-	Identifier       int64          `json:"identifier"`
+	Amount           *util.Amount   `json:"amount"`
+	Identifier       uint64         `json:"identifier"`
+	Secret           common.Hash    `json:"secret"`
+	SecretHash       common.Hash    `json:"secret_hash"`
+	LockTimeout      int64          `json:"lock_timeout"`
 }
@@ -0,0 +1,144 @@
+package payments
+
+import (
+	"context"
+	"time"
+
+	"github.com/cpurta/go-raiden-client/util"
+)
+
+const (
+	// DefaultPollInterval is the interval a Subscriber polls the node for new
+	// payment events if no interval is given.
+	DefaultPollInterval = 2 * time.Second
+	// DefaultMaxBackoff is the longest a Subscriber waits between polls while the
+	// node is unreachable if no maximum is given.
+	DefaultMaxBackoff = time.Minute
+	// DefaultPageSize is the number of events a Subscriber requests at once if the
+	// filter has no limit.
+	DefaultPageSize = 100
+)
+
+// SubscriberOptions configures how often a Subscriber polls the Raiden node.
+type SubscriberOptions struct {
+	// PollInterval is the time between two polls while the node is reachable.
+	PollInterval time.Duration
+	// MaxBackoff is the maximum time between two polls while the node is
+	// unreachable. The time between polls doubles after every failed poll.
+	MaxBackoff time.Duration
+}
+
+// Subscriber is an interface to receive payment events from a Raiden node as they
+// happen rather than listing them once.
+type Subscriber interface {
+	Subscribe(ctx context.Context, filter *EventFilter) (<-chan *Event, <-chan error)
+}
+
+var _ Subscriber = &defaultSubscriber{}
+
+// NewSubscriber will create a default subscriber that long-polls the given lister
+// for new payment events. If options is nil the default intervals are used.
+func NewSubscriber(lister Lister, options *SubscriberOptions) Subscriber {
+	var (
+		pollInterval = DefaultPollInterval
+		maxBackoff   = DefaultMaxBackoff
+	)
+
+	if options != nil && options.PollInterval > 0 {
+		pollInterval = options.PollInterval
+	}
+
+	if options != nil && options.MaxBackoff > 0 {
+		maxBackoff = options.MaxBackoff
+	}
+
+	return &defaultSubscriber{
+		lister: lister,
+		poller: util.NewPoller(pollInterval, maxBackoff),
+	}
+}
+
+type defaultSubscriber struct {
+	lister Lister
+	poller *util.Poller
+}
+
+// Subscribe will poll the node for payment events matching the filter and send
+// every new event on the returned event channel exactly once. The node appends
+// events to its history, so the subscriber pages through it with an offset that
+// moves past every event received and only requests events it has not seen. The
+// filter's Limit is the page size, DefaultPageSize if zero, and its Offset is
+// ignored. Full pages are followed by the next page right away until the
+// subscriber caught up with the node. Events outside of the filter's time range
+// are skipped. Errors while polling are sent on the error channel, which is
+// buffered and drops errors that are not received before the next one occurs.
+// Both channels are closed once the context is done.
+func (subscriber *defaultSubscriber) Subscribe(ctx context.Context, filter *EventFilter) (<-chan *Event, <-chan error) {
+	var (
+		events = make(chan *Event)
+		errs   = make(chan error, 1)
+		window = EventFilter{}
+	)
+
+	if filter != nil {
+		window = *filter
+	}
+
+	go subscriber.poll(ctx, window, events, errs)
+
+	return events, errs
+}
+
+func (subscriber *defaultSubscriber) poll(ctx context.Context, window EventFilter, events chan<- *Event, errs chan<- error) {
+	var (
+		page = EventFilter{
+			TokenAddress:  window.TokenAddress,
+			TargetAddress: window.TargetAddress,
+			Limit:         window.Limit,
+		}
+	)
+
+	defer close(events)
+	defer close(errs)
+
+	if page.Limit <= 0 {
+		page.Limit = DefaultPageSize
+	}
+
+	subscriber.poller.Poll(ctx, func() (bool, error) {
+		paymentEvents, err := subscriber.lister.ListEvents(ctx, &page)
+
+		if err != nil {
+			return false, err
+		}
+
+		for _, event := range paymentEvents {
+			if inWindow(&window, event) {
+				select {
+				case <-ctx.Done():
+					return false, nil
+				case events <- event:
+				}
+			}
+
+			page.Offset++
+		}
+
+		// a full page means the node may have more events, so the next page is
+		// requested right away
+		return len(paymentEvents) >= page.Limit, nil
+	}, func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	})
+}
+
+func inWindow(window *EventFilter, event *Event) bool {
+	if !window.Since.IsZero() && event.LogTime.Before(window.Since) {
+		return false
+	}
+
+	return window.Until.IsZero() || event.LogTime.Before(window.Until)
+}
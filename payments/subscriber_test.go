@@ -0,0 +1,173 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleSubscriber() {
+	var (
+		paymentClient *Client
+		config        = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		tokenAddress = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359") // DAI Stablecoin
+		ctx, cancel  = context.WithCancel(context.Background())
+	)
+
+	defer cancel()
+
+	paymentClient = NewClient(config, http.DefaultClient)
+
+	events, errs := paymentClient.Subscribe(ctx, &EventFilter{TokenAddress: tokenAddress, Since: time.Now()})
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			if event.IsReceived() {
+				fmt.Printf("received payment %d of %s\n", event.Identifier, event.Amount)
+			}
+		case err := <-errs:
+			fmt.Println("unable to poll payment events:", err)
+		}
+	}
+}
+
+// historyLister pages through an append-only event history like a Raiden node.
+// Errors are returned by the next calls before any page is served.
+type historyLister struct {
+	Lister
+
+	mutex   sync.Mutex
+	history []*Event
+	errs    []error
+	filters []EventFilter
+}
+
+func (lister *historyLister) ListEvents(ctx context.Context, filter *EventFilter) ([]*Event, error) {
+	lister.mutex.Lock()
+	defer lister.mutex.Unlock()
+
+	lister.filters = append(lister.filters, *filter)
+
+	if len(lister.errs) > 0 {
+		err := lister.errs[0]
+		lister.errs = lister.errs[1:]
+
+		return nil, err
+	}
+
+	if filter.Offset >= len(lister.history) {
+		return []*Event{}, nil
+	}
+
+	end := len(lister.history)
+
+	if filter.Limit > 0 && filter.Offset+filter.Limit < end {
+		end = filter.Offset + filter.Limit
+	}
+
+	return append([]*Event{}, lister.history[filter.Offset:end]...), nil
+}
+
+func (lister *historyLister) add(event *Event) {
+	lister.mutex.Lock()
+	defer lister.mutex.Unlock()
+
+	lister.history = append(lister.history, event)
+}
+
+func TestSubscriber(t *testing.T) {
+	var (
+		tokenAddress = common.HexToAddress("0x0f114A1E9Db192502E7856309cc899952b3db1ED")
+		history      = make([]*Event, 0)
+	)
+
+	for i := 1; i <= 6; i++ {
+		history = append(history, &Event{
+			EventName:  EventPaymentReceivedSuccess,
+			Amount:     util.NewAmount(int64(i * 10)),
+			Identifier: uint64(i),
+			LogTime:    time.Date(2018, 10, 30, 7, i, 0, 0, time.UTC),
+		})
+	}
+
+	var (
+		lister = &historyLister{
+			history: history[:5],
+			errs:    []error{errors.New("connection refused")},
+		}
+		subscriber = NewSubscriber(lister, &SubscriberOptions{
+			PollInterval: time.Millisecond,
+			MaxBackoff:   5 * time.Millisecond,
+		})
+		ctx, cancel = context.WithCancel(context.Background())
+		received    = make([]*Event, 0)
+	)
+
+	// more events than the limit, of which the first is before the time range
+	events, errs := subscriber.Subscribe(ctx, &EventFilter{TokenAddress: tokenAddress, Limit: 2, Offset: 10, Since: history[1].LogTime})
+
+	receive := func(count int) {
+		for len(received) < count {
+			select {
+			case event := <-events:
+				received = append(received, event)
+			case <-time.After(time.Second):
+				require.FailNow(t, "timed out waiting for payment events")
+			}
+		}
+	}
+
+	receive(4)
+	assert.Equal(t, history[1:5], received)
+	assert.EqualError(t, <-errs, "connection refused")
+
+	lister.add(history[5])
+
+	receive(5)
+	assert.Equal(t, history[1:6], received)
+
+	cancel()
+
+	for range events {
+	}
+
+	_, ok := <-errs
+	assert.False(t, ok)
+
+	lister.mutex.Lock()
+	defer lister.mutex.Unlock()
+
+	offsets := make([]int, 0)
+
+	for _, filter := range lister.filters {
+		assert.Equal(t, tokenAddress, filter.TokenAddress)
+		assert.Equal(t, 2, filter.Limit)
+		assert.True(t, filter.Since.IsZero())
+
+		if len(offsets) == 0 || offsets[len(offsets)-1] != filter.Offset {
+			offsets = append(offsets, filter.Offset)
+		}
+	}
+
+	// the failed first poll is retried before the pages are requested in turn
+	require.True(t, len(offsets) >= 4)
+	assert.Equal(t, []int{0, 2, 4, 5}, offsets[:4])
+}
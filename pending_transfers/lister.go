@@ -2,10 +2,7 @@ package pendingtransfers
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
-	"net/url"
 
 	"github.com/cpurta/go-raiden-client/config"
 	"github.com/cpurta/go-raiden-client/util"
@@ -41,110 +38,26 @@ type defaultLister struct {
 
 // ListAll will list all currently pending transfers on the Raiden node.
 func (lister *defaultLister) ListAll(ctx context.Context) ([]*Transfer, error) {
-	var (
-		url *url.URL
-		err error
-	)
-
-	if url, err = lister.getAllRequestURL(); err != nil {
-		return nil, err
-	}
-
-	return lister.getPendingTransfers(ctx, url)
+	return lister.getPendingTransfers(ctx, "pending_transfers")
 }
 
 func (lister *defaultLister) ListToken(ctx context.Context, tokenAddress common.Address) ([]*Transfer, error) {
-	var (
-		url *url.URL
-		err error
-	)
-
-	if url, err = lister.getTokenRequestURL(tokenAddress); err != nil {
-		return nil, err
-	}
-
-	return lister.getPendingTransfers(ctx, url)
+	return lister.getPendingTransfers(ctx, "pending_transfers", tokenAddress.Hex())
 }
 
 func (lister *defaultLister) ListChannel(ctx context.Context, tokenAddress common.Address, partnerAddress common.Address) ([]*Transfer, error) {
-	var (
-		url *url.URL
-		err error
-	)
-
-	if url, err = lister.getChannelRequestURL(tokenAddress, partnerAddress); err != nil {
-		return nil, err
-	}
-
-	return lister.getPendingTransfers(ctx, url)
+	return lister.getPendingTransfers(ctx, "pending_transfers", tokenAddress.Hex(), partnerAddress.Hex())
 }
 
-func (lister *defaultLister) getPendingTransfers(ctx context.Context, url *url.URL) ([]*Transfer, error) {
+func (lister *defaultLister) getPendingTransfers(ctx context.Context, pathSegments ...string) ([]*Transfer, error) {
 	var (
 		err       error
 		transfers = make([]*Transfer, 0)
-
-		request  *http.Request
-		response *http.Response
 	)
 
-	if request, err = http.NewRequest("GET", url.String(), nil); err != nil {
-		return nil, err
-	}
-
-	request = request.WithContext(ctx)
-
-	if response, err = lister.baseClient.HTTPClient.Do(request); err != nil {
-		return nil, err
-	}
-
-	defer response.Body.Close()
-
-	if err = json.NewDecoder(response.Body).Decode(&transfers); err != nil {
+	if err = lister.baseClient.Do(ctx, "GET", pathSegments, nil, &transfers); err != nil {
 		return nil, err
 	}
 
 	return transfers, nil
 }
-
-func (lister *defaultLister) getAllRequestURL() (*url.URL, error) {
-	var (
-		err        error
-		endpoint   = fmt.Sprintf("%s/api/%s/pending_transfers", lister.baseClient.Config.Host, lister.baseClient.Config.APIVersion)
-		requestURL *url.URL
-	)
-
-	if requestURL, err = url.Parse(endpoint); err != nil {
-		return nil, err
-	}
-
-	return requestURL, nil
-}
-
-func (lister *defaultLister) getChannelRequestURL(tokenAddress, partnerAddress common.Address) (*url.URL, error) {
-	var (
-		err        error
-		endpoint   = fmt.Sprintf("%s/api/%s/pending_transfers/%s/%s", lister.baseClient.Config.Host, lister.baseClient.Config.APIVersion, tokenAddress.Hex(), partnerAddress.Hex())
-		requestURL *url.URL
-	)
-
-	if requestURL, err = url.Parse(endpoint); err != nil {
-		return nil, err
-	}
-
-	return requestURL, nil
-}
-
-func (lister *defaultLister) getTokenRequestURL(tokenAddress common.Address) (*url.URL, error) {
-	var (
-		err        error
-		endpoint   = fmt.Sprintf("%s/api/%s/pending_transfers/%s", lister.baseClient.Config.Host, lister.baseClient.Config.APIVersion, tokenAddress.Hex())
-		requestURL *url.URL
-	)
-
-	if requestURL, err = url.Parse(endpoint); err != nil {
-		return nil, err
-	}
-
-	return requestURL, nil
-}
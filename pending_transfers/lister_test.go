@@ -2,13 +2,13 @@ package pendingtransfers
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"testing"
 
 	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -105,13 +105,13 @@ func TestLister(t *testing.T) {
 				&Transfer{
 					ChannelIdentifier:      int64(255),
 					Initiator:              common.HexToAddress("0x5E1a3601538f94c9e6D2B40F7589030ac5885FE7"),
-					LockedAmount:           int64(119),
-					PaymentIdentifier:      int64(1),
+					LockedAmount:           util.NewAmount(119),
+					PaymentIdentifier:      uint64(1),
 					Role:                   "initiator",
 					Target:                 common.HexToAddress("0x00AF5cBfc8dC76cd599aF623E60F763228906F3E"),
 					TokenAddress:           common.HexToAddress("0xd0A1E359811322d97991E03f863a0C30C2cF029C"),
 					TokenNetworkIdentifier: common.HexToAddress("0x111157460c0F41EfD9107239B7864c062aA8B978"),
-					TransferredAmount:      int64(331),
+					TransferredAmount:      util.NewAmount(331),
 				},
 			},
 		},
@@ -145,7 +145,7 @@ func TestLister(t *testing.T) {
 					),
 				)
 			},
-			expectedError:     errors.New("EOF"),
+			expectedError:     &util.APIError{StatusCode: http.StatusInternalServerError, Method: "GET", URL: "http://localhost:5001/api/v1/pending_transfers"},
 			expectedTransfers: nil,
 		},
 		testcase{
@@ -0,0 +1,796 @@
+package raidentest
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type errorResponse struct {
+	Errors string `json:"errors"`
+}
+
+type statusResponse struct {
+	Status       string `json:"status"`
+	BlocksToSync int64  `json:"blocks_to_sync,omitempty"`
+}
+
+type channelResponse struct {
+	TokenNetworkIdentifier string       `json:"token_network_identifier"`
+	ChannelIdentifier      int64        `json:"channel_identifier"`
+	PartnerAddress         string       `json:"partner_address"`
+	TokenAddress           string       `json:"token_address"`
+	Balance                *util.Amount `json:"balance"`
+	TotalDeposit           *util.Amount `json:"total_deposit"`
+	TotalWithdraw          *util.Amount `json:"total_withdraw"`
+	State                  string       `json:"state"`
+	SettleTimeout          int64        `json:"settle_timeout"`
+	RevealTimeout          int64        `json:"reveal_timeout"`
+}
+
+type openChannelRequest struct {
+	PartnerAddress common.Address `json:"partner_address"`
+	TokenAddress   common.Address `json:"token_address"`
+	TotalDeposit   *util.Amount   `json:"total_deposit"`
+	SettleTimeout  *int64         `json:"settle_timeout"`
+}
+
+type patchChannelRequest struct {
+	State         *string      `json:"state"`
+	TotalDeposit  *util.Amount `json:"total_deposit"`
+	TotalWithdraw *util.Amount `json:"total_withdraw"`
+}
+
+type paymentRequest struct {
+	Amount      *util.Amount `json:"amount"`
+	Identifier  uint64       `json:"identifier"`
+	Secret      *common.Hash `json:"secret"`
+	SecretHash  *common.Hash `json:"secret_hash"`
+	LockTimeout int64        `json:"lock_timeout"`
+}
+
+type paymentResponse struct {
+	InitiatorAddress string       `json:"initiator_address"`
+	TargetAddress    string       `json:"target_address"`
+	TokenAddress     string       `json:"token_address"`
+	Amount           *util.Amount `json:"amount"`
+	Identifier       uint64       `json:"identifier"`
+	Secret           *common.Hash `json:"secret,omitempty"`
+	SecretHash       common.Hash  `json:"secret_hash"`
+	LockTimeout      int64        `json:"lock_timeout,omitempty"`
+}
+
+type eventResponse struct {
+	Event        string       `json:"event"`
+	Amount       *util.Amount `json:"amount,omitempty"`
+	Initiator    string       `json:"initiator,omitempty"`
+	Target       string       `json:"target,omitempty"`
+	TokenAddress string       `json:"token_address"`
+	Identifier   uint64       `json:"identifier"`
+	Reason       string       `json:"reason,omitempty"`
+	LogTime      string       `json:"log_time"`
+}
+
+type partnerResponse struct {
+	PartnerAddress string `json:"partner_address"`
+	Channel        string `json:"channel"`
+}
+
+type connectionRequest struct {
+	Funds *util.Amount `json:"funds"`
+}
+
+type connectionResponse struct {
+	Funds       *util.Amount `json:"funds"`
+	SumDeposits *util.Amount `json:"sum_deposits"`
+	Channels    int64        `json:"channels"`
+}
+
+type pendingTransferResponse struct {
+	ChannelIdentifier      int64        `json:"channel_identifier"`
+	Initiator              string       `json:"initiator"`
+	LockedAmount           *util.Amount `json:"locked_amount"`
+	PaymentIdentifier      uint64       `json:"payment_identifier"`
+	Role                   string       `json:"role"`
+	Target                 string       `json:"target"`
+	TokenAddress           string       `json:"token_address"`
+	TokenNetworkIdentifier string       `json:"token_network_identifier"`
+	TransferredAmount      *util.Amount `json:"transferred_amount"`
+}
+
+// serveHTTP routes a request to the handler of the endpoint it targets. Requests are
+// served one at a time so handlers can use the node state without further locking.
+func (node *Node) serveHTTP(writer http.ResponseWriter, request *http.Request) {
+	var (
+		prefix = fmt.Sprintf("/api/%s/", APIVersion)
+		path   = request.URL.Path
+	)
+
+	if !strings.HasPrefix(path, prefix) {
+		writeError(writer, http.StatusNotFound, "unknown endpoint")
+		return
+	}
+
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(path, prefix), "/"), "/")
+
+	switch segments[0] {
+	case "status":
+		node.route(writer, request, len(segments) == 1, map[string]func(){
+			"GET": func() { node.getStatus(writer) },
+		})
+		return
+	case "version":
+		node.route(writer, request, len(segments) == 1, map[string]func(){
+			"GET": func() { writeJSON(writer, http.StatusOK, map[string]string{"version": Version}) },
+		})
+		return
+	case "settings":
+		node.route(writer, request, len(segments) == 1, map[string]func(){
+			"GET": func() {
+				writeJSON(writer, http.StatusOK, map[string]string{"pathfinding_service_address": node.pathfindingService})
+			},
+		})
+		return
+	}
+
+	if node.status != "ready" {
+		writeError(writer, http.StatusServiceUnavailable, fmt.Sprintf("Node is %s", node.status))
+		return
+	}
+
+	addresses, ok := parseAddresses(segments[1:])
+
+	if !ok && segments[0] != "tokens" {
+		writeError(writer, http.StatusBadRequest, "invalid address in path")
+		return
+	}
+
+	switch segments[0] {
+	case "address":
+		node.route(writer, request, len(segments) == 1, map[string]func(){
+			"GET": func() { node.getAddress(writer) },
+		})
+	case "tokens":
+		node.serveTokens(writer, request, segments[1:])
+	case "channels":
+		node.route(writer, request, len(segments) <= 3, map[string]func(){
+			"GET":   func() { node.getChannels(writer, addresses) },
+			"PUT":   func() { node.openChannel(writer, request, addresses) },
+			"PATCH": func() { node.patchChannel(writer, request, addresses) },
+		})
+	case "payments":
+		node.route(writer, request, len(segments) <= 3, map[string]func(){
+			"GET":  func() { node.getPayments(writer, request, addresses) },
+			"POST": func() { node.initiatePayment(writer, request, addresses) },
+		})
+	case "connections":
+		node.route(writer, request, len(segments) <= 2, map[string]func(){
+			"GET":    func() { node.getConnections(writer, addresses) },
+			"PUT":    func() { node.joinConnection(writer, request, addresses) },
+			"DELETE": func() { node.leaveConnection(writer, addresses) },
+		})
+	case "pending_transfers":
+		node.route(writer, request, len(segments) <= 3, map[string]func(){
+			"GET": func() { node.getPendingTransfers(writer, addresses) },
+		})
+	default:
+		writeError(writer, http.StatusNotFound, "unknown endpoint")
+	}
+}
+
+func (node *Node) route(writer http.ResponseWriter, request *http.Request, found bool, handlers map[string]func()) {
+	if !found {
+		writeError(writer, http.StatusNotFound, "unknown endpoint")
+		return
+	}
+
+	handler, ok := handlers[request.Method]
+
+	if !ok {
+		writeError(writer, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed", request.Method))
+		return
+	}
+
+	handler()
+}
+
+func (node *Node) serveTokens(writer http.ResponseWriter, request *http.Request, segments []string) {
+	var (
+		partners  = len(segments) == 2 && segments[1] == "partners"
+		addresses []common.Address
+		ok        bool
+	)
+
+	if partners {
+		segments = segments[:1]
+	}
+
+	if addresses, ok = parseAddresses(segments); !ok {
+		writeError(writer, http.StatusBadRequest, "invalid address in path")
+		return
+	}
+
+	switch {
+	case len(addresses) == 0:
+		node.route(writer, request, true, map[string]func(){
+			"GET": func() { node.getTokens(writer) },
+		})
+	case partners:
+		node.route(writer, request, true, map[string]func(){
+			"GET": func() { node.getPartners(writer, addresses[0]) },
+		})
+	default:
+		node.route(writer, request, len(addresses) == 1, map[string]func(){
+			"GET": func() { node.getTokenNetwork(writer, addresses[0]) },
+			"PUT": func() { node.registerTokenNetwork(writer, addresses[0]) },
+		})
+	}
+}
+
+func (node *Node) getAddress(writer http.ResponseWriter) {
+	writeJSON(writer, http.StatusOK, map[string]string{
+		"our_address": node.Address.Hex(),
+	})
+}
+
+func (node *Node) getStatus(writer http.ResponseWriter) {
+	writeJSON(writer, http.StatusOK, &statusResponse{
+		Status:       node.status,
+		BlocksToSync: node.blocksToSync,
+	})
+}
+
+func (node *Node) getTokens(writer http.ResponseWriter) {
+	tokens := make([]string, 0)
+
+	for _, token := range node.tokenOrder {
+		tokens = append(tokens, token.Hex())
+	}
+
+	writeJSON(writer, http.StatusOK, tokens)
+}
+
+func (node *Node) getTokenNetwork(writer http.ResponseWriter, token common.Address) {
+	network, ok := node.tokenNetworks[token]
+
+	if !ok {
+		writeError(writer, http.StatusNotFound, fmt.Sprintf("No token network registered for token %s", token.Hex()))
+		return
+	}
+
+	writeJSON(writer, http.StatusOK, network.Hex())
+}
+
+func (node *Node) registerTokenNetwork(writer http.ResponseWriter, token common.Address) {
+	if _, ok := node.tokenNetworks[token]; ok {
+		writeError(writer, http.StatusConflict, fmt.Sprintf("Token %s is already registered", token.Hex()))
+		return
+	}
+
+	writeJSON(writer, http.StatusCreated, map[string]string{
+		"token_network_address": node.registerToken(token).Hex(),
+	})
+}
+
+func (node *Node) getPartners(writer http.ResponseWriter, token common.Address) {
+	partners := make([]*partnerResponse, 0)
+
+	for _, key := range node.channelOrder {
+		if key.token != token {
+			continue
+		}
+
+		partners = append(partners, &partnerResponse{
+			PartnerAddress: key.partner.Hex(),
+			Channel:        fmt.Sprintf("/api/%s/channels/%s/%s", APIVersion, key.token.Hex(), key.partner.Hex()),
+		})
+	}
+
+	writeJSON(writer, http.StatusOK, partners)
+}
+
+func (node *Node) getChannels(writer http.ResponseWriter, addresses []common.Address) {
+	if len(addresses) == 2 {
+		channel, ok := node.channels[channelKey{token: addresses[0], partner: addresses[1]}]
+
+		if !ok {
+			writeError(writer, http.StatusNotFound, fmt.Sprintf("Channel with partner '%s' for token '%s' could not be found.", addresses[1].Hex(), addresses[0].Hex()))
+			return
+		}
+
+		writeJSON(writer, http.StatusOK, node.channelResponse(channel))
+		return
+	}
+
+	channels := make([]*channelResponse, 0)
+
+	for _, key := range node.channelOrder {
+		if len(addresses) == 1 && key.token != addresses[0] {
+			continue
+		}
+
+		channels = append(channels, node.channelResponse(node.channels[key]))
+	}
+
+	writeJSON(writer, http.StatusOK, channels)
+}
+
+func (node *Node) openChannel(writer http.ResponseWriter, request *http.Request, addresses []common.Address) {
+	var (
+		openRequest = &openChannelRequest{}
+	)
+
+	if len(addresses) != 0 {
+		writeError(writer, http.StatusMethodNotAllowed, "channels can only be opened on the channels endpoint")
+		return
+	}
+
+	if err := json.NewDecoder(request.Body).Decode(openRequest); err != nil {
+		writeError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, ok := node.tokenNetworks[openRequest.TokenAddress]; !ok {
+		writeError(writer, http.StatusConflict, fmt.Sprintf("Token network for token %s is not registered", openRequest.TokenAddress.Hex()))
+		return
+	}
+
+	if openRequest.TotalDeposit.Sign() < 0 {
+		writeError(writer, http.StatusConflict, "total_deposit must not be negative")
+		return
+	}
+
+	key := channelKey{token: openRequest.TokenAddress, partner: openRequest.PartnerAddress}
+
+	if existing, ok := node.channels[key]; ok && existing.state != StateSettled {
+		writeError(writer, http.StatusConflict, fmt.Sprintf("Channel with partner %s for token %s already exists", key.partner.Hex(), key.token.Hex()))
+		return
+	}
+
+	settleTimeout := DefaultSettleTimeout
+
+	if openRequest.SettleTimeout != nil {
+		settleTimeout = *openRequest.SettleTimeout
+	}
+
+	if settleTimeout < MinSettleTimeout || settleTimeout > MaxSettleTimeout {
+		writeError(writer, http.StatusConflict, fmt.Sprintf("Settlement timeout should be between %d and %d", MinSettleTimeout, MaxSettleTimeout))
+		return
+	}
+
+	channel := &channelState{
+		identifier:    node.nextChannelIdentifier,
+		token:         key.token,
+		partner:       key.partner,
+		totalDeposit:  util.NewAmountFromBig(openRequest.TotalDeposit.Big()),
+		totalWithdraw: util.NewAmount(0),
+		sent:          util.NewAmount(0),
+		received:      util.NewAmount(0),
+		state:         StateOpened,
+		settleTimeout: settleTimeout,
+	}
+
+	node.nextChannelIdentifier++
+
+	if _, ok := node.channels[key]; !ok {
+		node.channelOrder = append(node.channelOrder, key)
+	}
+
+	node.channels[key] = channel
+
+	writeJSON(writer, http.StatusCreated, node.channelResponse(channel))
+}
+
+func (node *Node) patchChannel(writer http.ResponseWriter, request *http.Request, addresses []common.Address) {
+	var (
+		patchRequest = &patchChannelRequest{}
+		fields       = 0
+	)
+
+	if len(addresses) != 2 {
+		writeError(writer, http.StatusMethodNotAllowed, "channels can only be patched by token and partner address")
+		return
+	}
+
+	channel, ok := node.channels[channelKey{token: addresses[0], partner: addresses[1]}]
+
+	if !ok {
+		writeError(writer, http.StatusNotFound, fmt.Sprintf("Channel with partner '%s' for token '%s' could not be found.", addresses[1].Hex(), addresses[0].Hex()))
+		return
+	}
+
+	if err := json.NewDecoder(request.Body).Decode(patchRequest); err != nil {
+		writeError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for _, set := range []bool{patchRequest.State != nil, patchRequest.TotalDeposit != nil, patchRequest.TotalWithdraw != nil} {
+		if set {
+			fields++
+		}
+	}
+
+	if fields != 1 {
+		writeError(writer, http.StatusBadRequest, "Exactly one of state, total_deposit or total_withdraw must be provided")
+		return
+	}
+
+	if channel.state != StateOpened {
+		writeError(writer, http.StatusConflict, fmt.Sprintf("Channel is %s and can not be modified", channel.state))
+		return
+	}
+
+	switch {
+	case patchRequest.State != nil:
+		if *patchRequest.State != StateClosed {
+			writeError(writer, http.StatusBadRequest, fmt.Sprintf("Invalid state %q, only closed is allowed", *patchRequest.State))
+			return
+		}
+
+		channel.state = StateClosed
+	case patchRequest.TotalDeposit != nil:
+		if patchRequest.TotalDeposit.Cmp(channel.totalDeposit) <= 0 {
+			writeError(writer, http.StatusConflict, "The provided total_deposit is not higher than the previous total deposit")
+			return
+		}
+
+		channel.totalDeposit = util.NewAmountFromBig(patchRequest.TotalDeposit.Big())
+	case patchRequest.TotalWithdraw != nil:
+		withdraw := patchRequest.TotalWithdraw.Sub(channel.totalWithdraw)
+
+		if withdraw.Sign() <= 0 {
+			writeError(writer, http.StatusConflict, "The provided total_withdraw is not higher than the previous total withdraw")
+			return
+		}
+
+		if withdraw.Cmp(channel.balance()) > 0 {
+			writeError(writer, http.StatusConflict, "The withdraw amount exceeds the channel balance")
+			return
+		}
+
+		channel.totalWithdraw = util.NewAmountFromBig(patchRequest.TotalWithdraw.Big())
+	}
+
+	writeJSON(writer, http.StatusOK, node.channelResponse(channel))
+}
+
+func (node *Node) initiatePayment(writer http.ResponseWriter, request *http.Request, addresses []common.Address) {
+	var (
+		err      error
+		payment  = &paymentRequest{}
+		response *paymentResponse
+	)
+
+	if len(addresses) != 2 {
+		writeError(writer, http.StatusMethodNotAllowed, "payments can only be initiated by token and target address")
+		return
+	}
+
+	if err = json.NewDecoder(request.Body).Decode(payment); err != nil {
+		writeError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if payment.Amount.Sign() <= 0 {
+		writeError(writer, http.StatusBadRequest, "amount must be greater than zero")
+		return
+	}
+
+	channel, ok := node.channels[channelKey{token: addresses[0], partner: addresses[1]}]
+
+	if !ok || channel.state != StateOpened {
+		writeError(writer, http.StatusConflict, "Payment couldn't be completed because: there is no route available")
+		return
+	}
+
+	if channel.balance().Cmp(payment.Amount) < 0 {
+		writeError(writer, http.StatusPaymentRequired, "Payment couldn't be completed because: insufficient balance")
+		return
+	}
+
+	if response, err = node.newPaymentResponse(addresses[0], addresses[1], payment); err != nil {
+		writeError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	channel.sent = channel.sent.Add(payment.Amount)
+
+	node.events = append(node.events, &paymentEvent{
+		event:      "EventPaymentSentSuccess",
+		token:      addresses[0],
+		amount:     payment.Amount,
+		target:     addresses[1],
+		identifier: response.Identifier,
+		logTime:    node.now().UTC(),
+	})
+
+	writeJSON(writer, http.StatusOK, response)
+}
+
+func (node *Node) newPaymentResponse(token, target common.Address, payment *paymentRequest) (*paymentResponse, error) {
+	var (
+		response = &paymentResponse{
+			InitiatorAddress: node.Address.Hex(),
+			TargetAddress:    target.Hex(),
+			TokenAddress:     token.Hex(),
+			Amount:           payment.Amount,
+			Identifier:       payment.Identifier,
+			Secret:           payment.Secret,
+			LockTimeout:      payment.LockTimeout,
+		}
+	)
+
+	if response.Identifier == 0 {
+		response.Identifier = node.nextPaymentIdentifier
+		node.nextPaymentIdentifier++
+	}
+
+	if payment.Secret == nil && payment.SecretHash == nil {
+		secret, err := randomHash()
+
+		if err != nil {
+			return nil, err
+		}
+
+		response.Secret = &secret
+	}
+
+	if response.Secret != nil {
+		response.SecretHash = common.Hash(sha256.Sum256(response.Secret.Bytes()))
+	}
+
+	if payment.SecretHash != nil {
+		if response.Secret != nil && *payment.SecretHash != response.SecretHash {
+			return nil, fmt.Errorf("secret_hash does not match the secret")
+		}
+
+		response.SecretHash = *payment.SecretHash
+	}
+
+	return response, nil
+}
+
+func (node *Node) getPayments(writer http.ResponseWriter, request *http.Request, addresses []common.Address) {
+	var (
+		events = make([]*eventResponse, 0)
+		query  = request.URL.Query()
+		limit  = -1
+		offset = 0
+	)
+
+	if value := query.Get("limit"); value != "" {
+		var err error
+
+		if limit, err = strconv.Atoi(value); err != nil || limit < 0 {
+			writeError(writer, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+	}
+
+	if value := query.Get("offset"); value != "" {
+		var err error
+
+		if offset, err = strconv.Atoi(value); err != nil || offset < 0 {
+			writeError(writer, http.StatusBadRequest, "offset must be a positive integer")
+			return
+		}
+	}
+
+	for _, event := range node.events {
+		if len(addresses) > 0 && event.token != addresses[0] {
+			continue
+		}
+
+		if len(addresses) > 1 && event.target != addresses[1] && event.initiator != addresses[1] {
+			continue
+		}
+
+		events = append(events, newEventResponse(event))
+	}
+
+	if offset > len(events) {
+		offset = len(events)
+	}
+
+	events = events[offset:]
+
+	if limit >= 0 && limit < len(events) {
+		events = events[:limit]
+	}
+
+	writeJSON(writer, http.StatusOK, events)
+}
+
+func (node *Node) getConnections(writer http.ResponseWriter, addresses []common.Address) {
+	var (
+		connections = make(map[string]*connectionResponse)
+	)
+
+	if len(addresses) != 0 {
+		writeError(writer, http.StatusMethodNotAllowed, "connections can only be listed on the connections endpoint")
+		return
+	}
+
+	for token, connection := range node.connections {
+		response := &connectionResponse{
+			Funds:       connection.funds,
+			SumDeposits: util.NewAmount(0),
+		}
+
+		for _, key := range node.channelOrder {
+			channel := node.channels[key]
+
+			if key.token != token || channel.state != StateOpened {
+				continue
+			}
+
+			response.SumDeposits = response.SumDeposits.Add(channel.totalDeposit)
+			response.Channels++
+		}
+
+		connections[token.Hex()] = response
+	}
+
+	writeJSON(writer, http.StatusOK, connections)
+}
+
+func (node *Node) joinConnection(writer http.ResponseWriter, request *http.Request, addresses []common.Address) {
+	var (
+		joinRequest = &connectionRequest{}
+	)
+
+	if len(addresses) != 1 {
+		writeError(writer, http.StatusMethodNotAllowed, "connections can only be joined by token address")
+		return
+	}
+
+	if err := json.NewDecoder(request.Body).Decode(joinRequest); err != nil {
+		writeError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, ok := node.tokenNetworks[addresses[0]]; !ok {
+		writeError(writer, http.StatusConflict, fmt.Sprintf("Token network for token %s is not registered", addresses[0].Hex()))
+		return
+	}
+
+	if joinRequest.Funds.Sign() <= 0 {
+		writeError(writer, http.StatusConflict, "funds must be greater than zero")
+		return
+	}
+
+	node.connections[addresses[0]] = &connectionState{
+		funds: joinRequest.Funds,
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (node *Node) leaveConnection(writer http.ResponseWriter, addresses []common.Address) {
+	var (
+		closed = make([]string, 0)
+	)
+
+	if len(addresses) != 1 {
+		writeError(writer, http.StatusMethodNotAllowed, "connections can only be left by token address")
+		return
+	}
+
+	for _, key := range node.channelOrder {
+		channel := node.channels[key]
+
+		if key.token != addresses[0] || channel.state != StateOpened {
+			continue
+		}
+
+		channel.state = StateClosed
+		closed = append(closed, key.partner.Hex())
+	}
+
+	delete(node.connections, addresses[0])
+
+	writeJSON(writer, http.StatusOK, closed)
+}
+
+func (node *Node) getPendingTransfers(writer http.ResponseWriter, addresses []common.Address) {
+	var (
+		transfers = make([]*pendingTransferResponse, 0)
+	)
+
+	for _, transfer := range node.pendingTransfers {
+		if len(addresses) > 0 && transfer.token != addresses[0] {
+			continue
+		}
+
+		if len(addresses) > 1 && transfer.partner != addresses[1] {
+			continue
+		}
+
+		transfers = append(transfers, &pendingTransferResponse{
+			ChannelIdentifier:      transfer.channelIdentifier,
+			Initiator:              transfer.initiator.Hex(),
+			LockedAmount:           transfer.lockedAmount,
+			PaymentIdentifier:      transfer.paymentIdentifier,
+			Role:                   transfer.role,
+			Target:                 transfer.target.Hex(),
+			TokenAddress:           transfer.token.Hex(),
+			TokenNetworkIdentifier: node.tokenNetworks[transfer.token].Hex(),
+			TransferredAmount:      transfer.transferred,
+		})
+	}
+
+	writeJSON(writer, http.StatusOK, transfers)
+}
+
+func (node *Node) channelResponse(channel *channelState) *channelResponse {
+	return &channelResponse{
+		TokenNetworkIdentifier: node.tokenNetworks[channel.token].Hex(),
+		ChannelIdentifier:      channel.identifier,
+		PartnerAddress:         channel.partner.Hex(),
+		TokenAddress:           channel.token.Hex(),
+		Balance:                channel.balance(),
+		TotalDeposit:           channel.totalDeposit,
+		TotalWithdraw:          channel.totalWithdraw,
+		State:                  channel.state,
+		SettleTimeout:          channel.settleTimeout,
+		RevealTimeout:          DefaultRevealTimeout,
+	}
+}
+
+func newEventResponse(event *paymentEvent) *eventResponse {
+	response := &eventResponse{
+		Event:        event.event,
+		Amount:       event.amount,
+		TokenAddress: event.token.Hex(),
+		Identifier:   event.identifier,
+		Reason:       event.reason,
+		LogTime:      event.logTime.Format(time.RFC3339Nano),
+	}
+
+	if event.initiator != (common.Address{}) {
+		response.Initiator = event.initiator.Hex()
+	}
+
+	if event.target != (common.Address{}) {
+		response.Target = event.target.Hex()
+	}
+
+	return response
+}
+
+func parseAddresses(segments []string) ([]common.Address, bool) {
+	addresses := make([]common.Address, 0, len(segments))
+
+	for _, segment := range segments {
+		if !common.IsHexAddress(segment) {
+			return nil, false
+		}
+
+		addresses = append(addresses, common.HexToAddress(segment))
+	}
+
+	return addresses, true
+}
+
+func writeJSON(writer http.ResponseWriter, status int, body interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+
+	json.NewEncoder(writer).Encode(body)
+}
+
+func writeError(writer http.ResponseWriter, status int, message string) {
+	writeJSON(writer, status, &errorResponse{
+		Errors: message,
+	})
+}
@@ -0,0 +1,293 @@
+// Package raidentest provides an in-process fake Raiden node for testing code
+// that uses the Raiden client without a live node. The fake node keeps in-memory
+// state for tokens, channels, deposits, payments, connections and pending
+// transfers and enforces the same state transitions as a real node.
+package raidentest
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// APIVersion is the API version served by the fake node.
+	APIVersion = "v1"
+
+	// DefaultSettleTimeout is the settle timeout used when a channel is opened
+	// without one.
+	DefaultSettleTimeout = int64(500)
+
+	// MinSettleTimeout and MaxSettleTimeout are the bounds of the settle timeout
+	// of a channel, as set by the token network contract. A channel opened with a
+	// settle timeout outside of them, including 0, is rejected with a conflict.
+	MinSettleTimeout = int64(500)
+	MaxSettleTimeout = int64(555428)
+
+	// DefaultRevealTimeout is the reveal timeout of every channel.
+	DefaultRevealTimeout = int64(50)
+
+	// Version is the Raiden version reported by the fake node.
+	Version = "0.200.0"
+)
+
+// Channel states used by the fake node.
+const (
+	StateOpened  = "opened"
+	StateClosed  = "closed"
+	StateSettled = "settled"
+)
+
+// Node is a fake Raiden node served by an httptest.Server. All exported methods
+// are safe for concurrent use and may be used by tests to set up state that a
+// real node would learn from the blockchain or from its partners.
+type Node struct {
+	Server  *httptest.Server
+	Address common.Address
+
+	mutex                 sync.Mutex
+	status                string
+	blocksToSync          int64
+	pathfindingService    string
+	tokenNetworks         map[common.Address]common.Address
+	tokenOrder            []common.Address
+	channels              map[channelKey]*channelState
+	channelOrder          []channelKey
+	connections           map[common.Address]*connectionState
+	events                []*paymentEvent
+	pendingTransfers      []*pendingTransfer
+	nextChannelIdentifier int64
+	nextPaymentIdentifier uint64
+	now                   func() time.Time
+}
+
+type channelKey struct {
+	token   common.Address
+	partner common.Address
+}
+
+type channelState struct {
+	identifier    int64
+	token         common.Address
+	partner       common.Address
+	totalDeposit  *util.Amount
+	totalWithdraw *util.Amount
+	sent          *util.Amount
+	received      *util.Amount
+	state         string
+	settleTimeout int64
+}
+
+type connectionState struct {
+	funds *util.Amount
+}
+
+type paymentEvent struct {
+	event      string
+	token      common.Address
+	amount     *util.Amount
+	initiator  common.Address
+	target     common.Address
+	identifier uint64
+	reason     string
+	logTime    time.Time
+}
+
+type pendingTransfer struct {
+	channelIdentifier int64
+	partner           common.Address
+	initiator         common.Address
+	target            common.Address
+	token             common.Address
+	lockedAmount      *util.Amount
+	paymentIdentifier uint64
+	role              string
+	transferred       *util.Amount
+}
+
+// NewNode starts a new fake Raiden node using the given address as the node's own
+// Ethereum address. The node must be closed by calling Close.
+func NewNode(address common.Address) *Node {
+	node := &Node{
+		Address:               address,
+		status:                "ready",
+		tokenNetworks:         make(map[common.Address]common.Address),
+		channels:              make(map[channelKey]*channelState),
+		connections:           make(map[common.Address]*connectionState),
+		nextChannelIdentifier: 1,
+		nextPaymentIdentifier: 1,
+		now:                   time.Now,
+	}
+
+	node.Server = httptest.NewServer(http.HandlerFunc(node.serveHTTP))
+
+	return node
+}
+
+// Config returns a client configuration pointing at the fake node.
+func (node *Node) Config() *config.Config {
+	return &config.Config{
+		Host:       node.Server.URL,
+		APIVersion: APIVersion,
+	}
+}
+
+// Close shuts down the fake node.
+func (node *Node) Close() {
+	node.Server.Close()
+}
+
+// SetStatus sets the sync status reported by the node. While the status is not
+// ready the node answers every other request with 503 Service Unavailable.
+func (node *Node) SetStatus(status string, blocksToSync int64) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.status = status
+	node.blocksToSync = blocksToSync
+}
+
+// SetPathfindingService sets the pathfinding service address reported in the
+// node settings.
+func (node *Node) SetPathfindingService(address string) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.pathfindingService = address
+}
+
+// RegisterToken registers a token network for the given token as if it had been
+// registered on chain and returns the token network address.
+func (node *Node) RegisterToken(token common.Address) common.Address {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	return node.registerToken(token)
+}
+
+// Settle settles a closed channel as if the settle timeout had expired.
+func (node *Node) Settle(token, partner common.Address) error {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	channel, ok := node.channels[channelKey{token: token, partner: partner}]
+
+	if !ok {
+		return fmt.Errorf("channel with partner %s for token %s not found", partner.Hex(), token.Hex())
+	}
+
+	if channel.state != StateClosed {
+		return fmt.Errorf("channel with partner %s for token %s is %s, only closed channels can be settled", partner.Hex(), token.Hex(), channel.state)
+	}
+
+	channel.state = StateSettled
+
+	return nil
+}
+
+// ReceivePayment records a payment received from a partner over an open channel
+// and returns the payment identifier used.
+func (node *Node) ReceivePayment(token, initiator common.Address, amount *util.Amount) (uint64, error) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	channel, ok := node.channels[channelKey{token: token, partner: initiator}]
+
+	if !ok || channel.state != StateOpened {
+		return 0, fmt.Errorf("no open channel with partner %s for token %s", initiator.Hex(), token.Hex())
+	}
+
+	identifier := node.nextPaymentIdentifier
+	node.nextPaymentIdentifier++
+
+	channel.received = channel.received.Add(amount)
+
+	node.events = append(node.events, &paymentEvent{
+		event:      "EventPaymentReceivedSuccess",
+		token:      token,
+		amount:     amount,
+		initiator:  initiator,
+		identifier: identifier,
+		logTime:    node.now().UTC(),
+	})
+
+	return identifier, nil
+}
+
+// AddPendingTransfer adds a pending transfer in the channel with the given partner.
+func (node *Node) AddPendingTransfer(token, partner, initiator, target common.Address, lockedAmount *util.Amount, paymentIdentifier uint64, role string) error {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	channel, ok := node.channels[channelKey{token: token, partner: partner}]
+
+	if !ok {
+		return fmt.Errorf("channel with partner %s for token %s not found", partner.Hex(), token.Hex())
+	}
+
+	node.pendingTransfers = append(node.pendingTransfers, &pendingTransfer{
+		channelIdentifier: channel.identifier,
+		partner:           partner,
+		initiator:         initiator,
+		target:            target,
+		token:             token,
+		lockedAmount:      lockedAmount,
+		paymentIdentifier: paymentIdentifier,
+		role:              role,
+		transferred:       util.NewAmount(0),
+	})
+
+	return nil
+}
+
+// ClearPendingTransfers removes all pending transfers, e.g. once their locks have
+// been unlocked.
+func (node *Node) ClearPendingTransfers() {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.pendingTransfers = nil
+}
+
+func (node *Node) registerToken(token common.Address) common.Address {
+	if network, ok := node.tokenNetworks[token]; ok {
+		return network
+	}
+
+	network := deriveAddress("token_network", token.Bytes())
+
+	node.tokenNetworks[token] = network
+	node.tokenOrder = append(node.tokenOrder, token)
+
+	return network
+}
+
+func (channel *channelState) balance() *util.Amount {
+	return channel.totalDeposit.Sub(channel.totalWithdraw).Add(channel.received).Sub(channel.sent)
+}
+
+// deriveAddress deterministically derives a fake contract address from a label and
+// some data so that repeated runs produce the same addresses.
+func deriveAddress(label string, data []byte) common.Address {
+	hash := sha256.Sum256(append([]byte(label), data...))
+
+	return common.BytesToAddress(hash[:])
+}
+
+func randomHash() (common.Hash, error) {
+	var hash common.Hash
+
+	if _, err := rand.Read(hash[:]); err != nil {
+		return hash, err
+	}
+
+	return hash, nil
+}
@@ -0,0 +1,200 @@
+package raidentest_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	raidenclient "github.com/cpurta/go-raiden-client"
+	"github.com/cpurta/go-raiden-client/channels"
+	"github.com/cpurta/go-raiden-client/payments"
+	"github.com/cpurta/go-raiden-client/raidentest"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeChannelLifecycle(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		ourAddress     = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+		tokenAddress   = common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226")
+		partnerAddress = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
+		node           = raidentest.NewNode(ourAddress)
+		client         = raidenclient.NewClient(node.Config(), http.DefaultClient)
+	)
+
+	defer node.Close()
+
+	address, err := client.Address().Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ourAddress, address)
+
+	// channels can only be opened in registered token networks
+
+	_, err = client.Channels().Open(ctx, tokenAddress, partnerAddress, util.NewAmount(100), 0)
+	assert.True(t, util.IsConflict(err))
+
+	networkAddress, err := client.Tokens().Register(ctx, tokenAddress)
+	require.NoError(t, err)
+
+	_, err = client.Tokens().Register(ctx, tokenAddress)
+	assert.True(t, util.IsConflict(err))
+
+	tokens, err := client.Tokens().List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Address{tokenAddress}, tokens)
+
+	channel, err := client.Channels().Open(ctx, tokenAddress, partnerAddress, util.NewAmount(100), 0)
+	require.NoError(t, err)
+	assert.Equal(t, networkAddress, channel.TokenNetworkIdentifier)
+	assert.Equal(t, channels.StateOpened, channel.State)
+	assert.Equal(t, raidentest.DefaultSettleTimeout, channel.SettleTimeout)
+
+	channel, err = client.Channels().IncreaseDeposit(ctx, tokenAddress, partnerAddress, util.NewAmount(150))
+	require.NoError(t, err)
+	assert.Equal(t, util.NewAmount(150), channel.Balance)
+
+	_, err = client.Channels().IncreaseDeposit(ctx, tokenAddress, partnerAddress, util.NewAmount(150))
+	assert.True(t, util.IsConflict(err))
+
+	// payments are limited by the channel balance
+
+	_, err = client.Payments().Initiate(ctx, tokenAddress, partnerAddress, util.NewAmount(200), nil)
+	assert.True(t, util.IsPaymentRequired(err))
+
+	payment, err := client.Payments().Initiate(ctx, tokenAddress, partnerAddress, util.NewAmount(50), nil)
+	require.NoError(t, err)
+	assert.Equal(t, util.NewAmount(50), payment.Amount)
+	assert.NotEqual(t, common.Hash{}, payment.SecretHash)
+
+	_, err = node.ReceivePayment(tokenAddress, partnerAddress, util.NewAmount(20))
+	require.NoError(t, err)
+
+	events, err := client.Payments().List(ctx, tokenAddress, partnerAddress)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, payments.EventPaymentSentSuccess, events[0].EventName)
+	assert.Equal(t, payments.EventPaymentReceivedSuccess, events[1].EventName)
+
+	channel, err = client.Channels().Withdraw(ctx, tokenAddress, partnerAddress, util.NewAmount(20))
+	require.NoError(t, err)
+	assert.Equal(t, util.NewAmount(100), channel.Balance)
+
+	// closed channels are settled by the node and can no longer be modified, which
+	// the client already refuses locally
+
+	channel, err = client.Channels().Close(ctx, tokenAddress, partnerAddress)
+	require.NoError(t, err)
+	assert.Equal(t, channels.StateClosed, channel.State)
+
+	require.NoError(t, node.Settle(tokenAddress, partnerAddress))
+
+	_, err = client.Channels().IncreaseDeposit(ctx, tokenAddress, partnerAddress, util.NewAmount(500))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "channel is settled")
+
+	_, err = client.Payments().Initiate(ctx, tokenAddress, partnerAddress, util.NewAmount(1), nil)
+	assert.True(t, util.IsConflict(err))
+}
+
+func TestNodeConnections(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		tokenAddress   = common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226")
+		partnerAddress = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
+		node           = raidentest.NewNode(common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"))
+		client         = raidenclient.NewClient(node.Config(), http.DefaultClient)
+	)
+
+	defer node.Close()
+
+	node.RegisterToken(tokenAddress)
+
+	require.NoError(t, client.Connections().Join(ctx, tokenAddress, util.NewAmount(1000)))
+
+	_, err := client.Channels().Open(ctx, tokenAddress, partnerAddress, util.NewAmount(400), 0)
+	require.NoError(t, err)
+
+	require.NoError(t, node.AddPendingTransfer(tokenAddress, partnerAddress, partnerAddress, partnerAddress, util.NewAmount(10), 7, "initiator"))
+
+	transfers, err := client.PendingTransfers().ListChannel(ctx, tokenAddress, partnerAddress)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, uint64(7), transfers[0].PaymentIdentifier)
+
+	connections, err := client.Connections().List(ctx)
+	require.NoError(t, err)
+	require.Contains(t, connections, tokenAddress)
+	assert.Equal(t, util.NewAmount(400), connections[tokenAddress].SumDeposits)
+	assert.Equal(t, int64(1), connections[tokenAddress].Channels)
+
+	closed, err := client.Connections().Leave(ctx, tokenAddress)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Address{partnerAddress}, closed)
+
+	channel, err := client.Channels().Get(ctx, tokenAddress, partnerAddress)
+	require.NoError(t, err)
+	assert.Equal(t, channels.StateClosed, channel.State)
+}
+
+func TestNodeIdempotentPayment(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		tokenAddress   = common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226")
+		partnerAddress = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
+		node           = raidentest.NewNode(common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"))
+		client         = raidenclient.NewClient(node.Config(), http.DefaultClient)
+	)
+
+	defer node.Close()
+
+	node.RegisterToken(tokenAddress)
+
+	_, err := client.Channels().Open(ctx, tokenAddress, partnerAddress, util.NewAmount(100), 0)
+	require.NoError(t, err)
+
+	first, err := client.Payments().Pay(ctx, "order-1", tokenAddress, partnerAddress, util.NewAmount(30), nil)
+	require.NoError(t, err)
+	require.NotNil(t, first.Payment)
+
+	second, err := client.Payments().Pay(ctx, "order-1", tokenAddress, partnerAddress, util.NewAmount(30), nil)
+	require.NoError(t, err)
+	assert.Nil(t, second.Payment)
+	assert.Equal(t, first.Identifier, second.Event.Identifier)
+
+	channel, err := client.Channels().Get(ctx, tokenAddress, partnerAddress)
+	require.NoError(t, err)
+	assert.Equal(t, util.NewAmount(70), channel.Balance)
+}
+
+func TestNodeSettleTimeout(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		tokenAddress = common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226")
+		node         = raidentest.NewNode(common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"))
+		client       = raidenclient.NewClient(node.Config(), http.DefaultClient)
+	)
+
+	defer node.Close()
+
+	node.RegisterToken(tokenAddress)
+
+	// like a real node, an explicit settle timeout of 0 is out of range
+	request, err := http.NewRequest("PUT", node.Server.URL+"/api/v1/channels", strings.NewReader(`{"partner_address":"0x61C808D82A3Ac53231750daDc13c777b59310bD9","token_address":"0x2a65Aca4D5fC5B5C859090a6c34d164135398226","total_deposit":100,"settle_timeout":0}`))
+	require.NoError(t, err)
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+
+	_, err = client.Channels().Open(ctx, tokenAddress, common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9"), util.NewAmount(100), raidentest.MaxSettleTimeout+1)
+	assert.True(t, util.IsConflict(err))
+
+	channel, err := client.Channels().Open(ctx, tokenAddress, common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9"), util.NewAmount(100), raidentest.MinSettleTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, raidentest.MinSettleTimeout, channel.SettleTimeout)
+}
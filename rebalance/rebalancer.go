@@ -0,0 +1,355 @@
+// Package rebalance keeps the outbound capacity of the channels of a Raiden node
+// above a target share of their deposits by topping up drained channels.
+package rebalance
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/cpurta/go-raiden-client/channels"
+	"github.com/cpurta/go-raiden-client/connections"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Target is the desired outbound capacity of the channels of a token network.
+type Target struct {
+	TokenAddress common.Address
+	// Ratio is the minimum share of the total deposit of a channel that should be
+	// available as balance to send payments, greater than 0 and less than 1.
+	Ratio float64
+	// Budget is the maximum amount deposited into the channels of the token
+	// network in one rebalance. If it is nil the funds of the node's connection
+	// to the token network that are not yet deposited are used as budget.
+	Budget *util.Amount
+}
+
+// TopUp is a planned deposit into a channel whose balance fell below the target
+// ratio of its total deposit.
+type TopUp struct {
+	Channel *channels.Channel
+	// Ratio is the current share of the total deposit available as balance.
+	Ratio float64
+	// Amount is the amount deposited into the channel.
+	Amount *util.Amount
+	// Balance is the balance of the channel after the top-up. The top-up deposits
+	// up to it, so deposits made into the channel since planning count toward it.
+	Balance *util.Amount
+	// TotalDeposit is the planned total deposit of the channel after the top-up.
+	TotalDeposit *util.Amount
+	// Partial is set if the budget did not cover the full top-up.
+	Partial bool
+}
+
+// TokenPlan holds the top-ups of the channels of a single token network.
+type TokenPlan struct {
+	Target *Target
+	// Budget is the budget used for the token network, which is the target's
+	// budget or the undeposited funds of the node's connection.
+	Budget *util.Amount
+	// Required is the amount needed to bring every open channel up to the ratio.
+	Required *util.Amount
+	// Planned is the amount that will be deposited within the budget.
+	Planned *util.Amount
+	TopUps  []*TopUp
+}
+
+// Shortfall returns the amount missing from the budget to reach the target ratio
+// on every channel.
+func (plan *TokenPlan) Shortfall() *util.Amount {
+	return plan.Required.Sub(plan.Planned)
+}
+
+// Plan holds the top-ups of every targeted token network in the order of the
+// targets.
+type Plan struct {
+	Tokens []*TokenPlan
+}
+
+// TopUps returns the top-ups of all token networks in the order they are made.
+func (plan *Plan) TopUps() []*TopUp {
+	topUps := make([]*TopUp, 0)
+
+	for _, tokenPlan := range plan.Tokens {
+		topUps = append(topUps, tokenPlan.TopUps...)
+	}
+
+	return topUps
+}
+
+// Result is the outcome of a rebalance. Channels holds the channels as returned
+// by the node after each top-up, in the order of the plan, and is empty for a dry
+// run. A top-up whose balance was already reached leaves its channel unchanged.
+type Result struct {
+	Plan     *Plan
+	DryRun   bool
+	Channels []*channels.Channel
+}
+
+// Options configures a Rebalancer.
+type Options struct {
+	// DryRun only plans the top-ups without depositing anything.
+	DryRun bool
+}
+
+// Rebalancer is an interface to plan and make the deposits that bring the
+// channels of a node up to their target ratios.
+type Rebalancer interface {
+	Plan(ctx context.Context, targets []*Target) (*Plan, error)
+	Execute(ctx context.Context, plan *Plan) (*Result, error)
+	Rebalance(ctx context.Context, targets []*Target) (*Result, error)
+}
+
+var _ Rebalancer = &defaultRebalancer{}
+
+// NewRebalancer will create a default rebalancer that lists channels with the
+// channel lister, deposits into them with the deposit adder and looks up
+// connection funds with the connection lister. If options is nil top-ups are
+// made.
+func NewRebalancer(channelLister channels.Lister, adder channels.DepositAdder, connectionLister connections.Lister, options *Options) Rebalancer {
+	var (
+		rebalancer = &defaultRebalancer{
+			channelLister:    channelLister,
+			adder:            adder,
+			connectionLister: connectionLister,
+		}
+	)
+
+	if options != nil {
+		rebalancer.dryRun = options.DryRun
+	}
+
+	return rebalancer
+}
+
+type defaultRebalancer struct {
+	channelLister    channels.Lister
+	adder            channels.DepositAdder
+	connectionLister connections.Lister
+	dryRun           bool
+}
+
+// Plan will list the open channels of every target's token network and plan the
+// top-ups that bring their balance up to the target ratio of their total deposit.
+// Within a token network the most drained channels are topped up first until the
+// budget is spent, so the last top-up may only be partial.
+func (rebalancer *defaultRebalancer) Plan(ctx context.Context, targets []*Target) (*Plan, error) {
+	var (
+		err              error
+		nodeConnections  connections.Connections
+		tokenChannels    []*channels.Channel
+		tokenPlan        *TokenPlan
+		plan             = &Plan{Tokens: make([]*TokenPlan, 0, len(targets))}
+		needsConnections = false
+		targeted         = make(map[common.Address]bool, len(targets))
+	)
+
+	for _, target := range targets {
+		if err = validateTarget(target); err != nil {
+			return nil, err
+		}
+
+		if targeted[target.TokenAddress] {
+			return nil, fmt.Errorf("token %s is targeted more than once", target.TokenAddress.Hex())
+		}
+
+		targeted[target.TokenAddress] = true
+		needsConnections = needsConnections || target.Budget == nil
+	}
+
+	if needsConnections {
+		if nodeConnections, err = rebalancer.connectionLister.List(ctx); err != nil {
+			return nil, util.WrapError(err, "unable to list connections")
+		}
+	}
+
+	for _, target := range targets {
+		if tokenChannels, err = rebalancer.channelLister.ListByToken(ctx, target.TokenAddress); err != nil {
+			return nil, util.WrapError(err, "unable to list channels of token %s", target.TokenAddress.Hex())
+		}
+
+		if tokenPlan, err = planToken(target, budget(target, nodeConnections), tokenChannels); err != nil {
+			return nil, err
+		}
+
+		plan.Tokens = append(plan.Tokens, tokenPlan)
+	}
+
+	return plan, nil
+}
+
+// Execute will make the top-ups of the plan in order. Every top-up deposits until
+// the channel's balance reaches the planned balance, computed from the channel as
+// read right before depositing, so the plan's totals are never sent as outdated
+// total deposits. It stops at the first deposit that fails and returns the
+// channels topped up so far along with the error.
+func (rebalancer *defaultRebalancer) Execute(ctx context.Context, plan *Plan) (*Result, error) {
+	var (
+		err     error
+		channel *channels.Channel
+		result  = &Result{Plan: plan, Channels: make([]*channels.Channel, 0)}
+	)
+
+	for _, topUp := range plan.TopUps() {
+		if channel, _, err = rebalancer.adder.DepositUpTo(ctx, topUp.Channel.TokenAddress, topUp.Channel.PartnerAddress, topUp.Balance); err != nil {
+			return result, util.WrapError(err, "unable to top up channel %d with partner %s", topUp.Channel.ChannelIdentifier, topUp.Channel.PartnerAddress.Hex())
+		}
+
+		result.Channels = append(result.Channels, channel)
+	}
+
+	return result, nil
+}
+
+// Rebalance will plan the top-ups for the targets and make them, unless the
+// rebalancer is in dry-run mode, in which case only the plan is returned.
+func (rebalancer *defaultRebalancer) Rebalance(ctx context.Context, targets []*Target) (*Result, error) {
+	plan, err := rebalancer.Plan(ctx, targets)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rebalancer.dryRun {
+		return &Result{Plan: plan, DryRun: true, Channels: make([]*channels.Channel, 0)}, nil
+	}
+
+	return rebalancer.Execute(ctx, plan)
+}
+
+func validateTarget(target *Target) error {
+	if target.Ratio <= 0 || target.Ratio >= 1 {
+		return fmt.Errorf("target ratio %v of token %s must be greater than 0 and less than 1", target.Ratio, target.TokenAddress.Hex())
+	}
+
+	if target.Budget != nil && target.Budget.Sign() < 0 {
+		return fmt.Errorf("budget %s of token %s must not be negative", target.Budget, target.TokenAddress.Hex())
+	}
+
+	return nil
+}
+
+// budget returns the target's budget or the funds of the node's connection to the
+// token network that are not deposited into channels yet.
+func budget(target *Target, nodeConnections connections.Connections) *util.Amount {
+	if target.Budget != nil {
+		return target.Budget
+	}
+
+	connection, ok := nodeConnections[target.TokenAddress]
+
+	if !ok {
+		return util.NewAmount(0)
+	}
+
+	if remaining := connection.Funds.Sub(connection.SumDeposits); remaining.Sign() > 0 {
+		return remaining
+	}
+
+	return util.NewAmount(0)
+}
+
+func planToken(target *Target, budget *util.Amount, tokenChannels []*channels.Channel) (*TokenPlan, error) {
+	var (
+		ratio     = new(big.Rat)
+		remaining = budget.Big()
+		required  = new(big.Int)
+		planned   = new(big.Int)
+		topUps    = make([]*TopUp, 0)
+	)
+
+	if ratio.SetFloat64(target.Ratio) == nil {
+		return nil, fmt.Errorf("target ratio %v of token %s is not a finite number", target.Ratio, target.TokenAddress.Hex())
+	}
+
+	for _, channel := range tokenChannels {
+		if channel.State != channels.StateOpened {
+			continue
+		}
+
+		if needed := topUpAmount(ratio, channel.Balance.Big(), channel.TotalDeposit.Big()); needed.Sign() > 0 {
+			required.Add(required, needed)
+
+			topUps = append(topUps, &TopUp{
+				Channel: channel,
+				Ratio:   currentRatio(channel),
+				Amount:  util.NewAmountFromBig(needed),
+			})
+		}
+	}
+
+	sort.SliceStable(topUps, func(i, j int) bool {
+		if topUps[i].Ratio != topUps[j].Ratio {
+			return topUps[i].Ratio < topUps[j].Ratio
+		}
+
+		return topUps[i].Channel.PartnerAddress.Hex() < topUps[j].Channel.PartnerAddress.Hex()
+	})
+
+	funded := make([]*TopUp, 0, len(topUps))
+
+	for _, topUp := range topUps {
+		if remaining.Sign() <= 0 {
+			break
+		}
+
+		amount := topUp.Amount.Big()
+
+		if amount.Cmp(remaining) > 0 {
+			amount.Set(remaining)
+			topUp.Amount = util.NewAmountFromBig(amount)
+			topUp.Partial = true
+		}
+
+		remaining.Sub(remaining, amount)
+		planned.Add(planned, amount)
+
+		topUp.Balance = topUp.Channel.Balance.Add(topUp.Amount)
+		topUp.TotalDeposit = topUp.Channel.TotalDeposit.Add(topUp.Amount)
+		funded = append(funded, topUp)
+	}
+
+	return &TokenPlan{
+		Target:   target,
+		Budget:   budget,
+		Required: util.NewAmountFromBig(required),
+		Planned:  util.NewAmountFromBig(planned),
+		TopUps:   funded,
+	}, nil
+}
+
+// topUpAmount returns the smallest deposit x for which (balance + x) / (deposit +
+// x) reaches the ratio, i.e. x = (ratio * deposit - balance) / (1 - ratio) rounded
+// up, or zero if the ratio is already reached.
+func topUpAmount(ratio *big.Rat, balance, deposit *big.Int) *big.Int {
+	var (
+		numerator   = new(big.Rat).Sub(new(big.Rat).Mul(ratio, new(big.Rat).SetInt(deposit)), new(big.Rat).SetInt(balance))
+		denominator = new(big.Rat).Sub(big.NewRat(1, 1), ratio)
+		amount      = new(big.Rat).Quo(numerator, denominator)
+		rounded     = new(big.Int).Quo(amount.Num(), amount.Denom())
+	)
+
+	if amount.Sign() <= 0 {
+		return new(big.Int)
+	}
+
+	if !amount.IsInt() {
+		rounded.Add(rounded, big.NewInt(1))
+	}
+
+	return rounded
+}
+
+// currentRatio returns the share of the total deposit of a channel available as
+// balance. A channel without deposit has a ratio of zero.
+func currentRatio(channel *channels.Channel) float64 {
+	if channel.TotalDeposit.Sign() <= 0 {
+		return 0
+	}
+
+	ratio, _ := new(big.Rat).SetFrac(channel.Balance.Big(), channel.TotalDeposit.Big()).Float64()
+
+	return ratio
+}
@@ -0,0 +1,400 @@
+package rebalance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/channels"
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/connections"
+	"github.com/cpurta/go-raiden-client/payments"
+	"github.com/cpurta/go-raiden-client/raidentest"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleRebalancer() {
+	var (
+		config = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		tokenAddress  = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359") // DAI Stablecoin
+		channelClient = channels.NewClient(config, http.DefaultClient)
+		rebalancer    = NewRebalancer(channelClient, channelClient, connections.NewLister(config, http.DefaultClient), &Options{DryRun: true})
+		result        *Result
+		err           error
+	)
+
+	targets := []*Target{
+		&Target{TokenAddress: tokenAddress, Ratio: 0.4, Budget: util.NewAmount(1000000)},
+	}
+
+	if result, err = rebalancer.Rebalance(context.Background(), targets); err != nil {
+		panic(fmt.Sprintf("unable to plan rebalance: %s", err.Error()))
+	}
+
+	for _, topUp := range result.Plan.TopUps() {
+		fmt.Printf("would deposit %s into channel %d\n", topUp.Amount, topUp.Channel.ChannelIdentifier)
+	}
+}
+
+var (
+	ourAddress = common.HexToAddress("0x7a38bB1e7d6E1F1ABb2f2da2E3B0b2EBA9E48D75")
+	testToken  = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+	otherToken = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359")
+	partnerA   = common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9")
+	partnerB   = common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226")
+	partnerC   = common.HexToAddress("0x0F5D2fB29fb7d3CFeE444a200298f468908cC942")
+)
+
+func newChannel(identifier int64, token, partner common.Address, balance, deposit int64, state channels.ChannelState) *channels.Channel {
+	return &channels.Channel{
+		ChannelIdentifier: identifier,
+		TokenAddress:      token,
+		PartnerAddress:    partner,
+		Balance:           util.NewAmount(balance),
+		TotalDeposit:      util.NewAmount(deposit),
+		State:             state,
+	}
+}
+
+// newNode starts a fake node with the given channels, which are opened in order
+// with their total deposit and drained to their balance by payments, and with a
+// connection holding the given funds for every token in funds.
+func newNode(t *testing.T, channelList []*channels.Channel, funds map[common.Address]int64) (*raidentest.Node, *channels.Client) {
+	var (
+		ctx           = context.Background()
+		node          = raidentest.NewNode(ourAddress)
+		channelClient = channels.NewClient(node.Config(), http.DefaultClient)
+		initiator     = payments.NewInitiator(node.Config(), http.DefaultClient)
+		joiner        = connections.NewJoiner(node.Config(), http.DefaultClient)
+		channel       *channels.Channel
+		err           error
+	)
+
+	for _, expected := range channelList {
+		node.RegisterToken(expected.TokenAddress)
+
+		channel, err = channelClient.Open(ctx, expected.TokenAddress, expected.PartnerAddress, expected.TotalDeposit, 0)
+		require.NoError(t, err)
+		require.Equal(t, expected.ChannelIdentifier, channel.ChannelIdentifier)
+
+		if sent := expected.TotalDeposit.Sub(expected.Balance); sent.Sign() > 0 {
+			_, err = initiator.Initiate(ctx, expected.TokenAddress, expected.PartnerAddress, sent, nil)
+			require.NoError(t, err)
+		}
+
+		if expected.State == channels.StateClosed || expected.State == channels.StateSettled {
+			_, err = channelClient.Close(ctx, expected.TokenAddress, expected.PartnerAddress)
+			require.NoError(t, err)
+		}
+
+		if expected.State == channels.StateSettled {
+			require.NoError(t, node.Settle(expected.TokenAddress, expected.PartnerAddress))
+		}
+	}
+
+	for token, amount := range funds {
+		node.RegisterToken(token)
+		require.NoError(t, joiner.Join(ctx, token, util.NewAmount(amount)))
+	}
+
+	return node, channelClient
+}
+
+func newRebalancer(node *raidentest.Node, options *Options) Rebalancer {
+	var (
+		channelClient = channels.NewClient(node.Config(), http.DefaultClient)
+	)
+
+	return NewRebalancer(channelClient, channelClient, connections.NewLister(node.Config(), http.DefaultClient), options)
+}
+
+func TestRebalancerPlan(t *testing.T) {
+	type testcase struct {
+		name             string
+		channels         []*channels.Channel
+		funds            map[common.Address]int64
+		targets          []*Target
+		expectedTopUps   map[int64]int64
+		expectedPartial  []int64
+		expectedRequired int64
+		expectedPlanned  int64
+		expectedError    error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "tops up drained channels",
+			channels: []*channels.Channel{
+				newChannel(1, testToken, partnerA, 100, 1000, channels.StateOpened),
+				newChannel(2, testToken, partnerB, 600, 1000, channels.StateOpened),
+				newChannel(3, otherToken, partnerC, 0, 1000, channels.StateOpened),
+			},
+			targets: []*Target{
+				&Target{TokenAddress: testToken, Ratio: 0.5, Budget: util.NewAmount(10000)},
+			},
+			// (0.5 * 1000 - 100) / (1 - 0.5) = 800
+			expectedTopUps:   map[int64]int64{1: 800},
+			expectedRequired: 800,
+			expectedPlanned:  800,
+		},
+		testcase{
+			name: "rounds top-ups up",
+			channels: []*channels.Channel{
+				newChannel(1, testToken, partnerA, 0, 10, channels.StateOpened),
+			},
+			targets: []*Target{
+				&Target{TokenAddress: testToken, Ratio: 0.4, Budget: util.NewAmount(10000)},
+			},
+			// (0.4 * 10 - 0) / 0.6 = 6.67
+			expectedTopUps:   map[int64]int64{1: 7},
+			expectedRequired: 7,
+			expectedPlanned:  7,
+		},
+		testcase{
+			name: "spends the budget on the most drained channels first",
+			channels: []*channels.Channel{
+				newChannel(1, testToken, partnerA, 400, 1000, channels.StateOpened),
+				newChannel(2, testToken, partnerB, 0, 1000, channels.StateOpened),
+				newChannel(3, testToken, partnerC, 300, 1000, channels.StateOpened),
+			},
+			targets: []*Target{
+				&Target{TokenAddress: testToken, Ratio: 0.5, Budget: util.NewAmount(1200)},
+			},
+			expectedTopUps:   map[int64]int64{2: 1000, 3: 200},
+			expectedPartial:  []int64{3},
+			expectedRequired: 1600,
+			expectedPlanned:  1200,
+		},
+		testcase{
+			name: "skips channels that are not open",
+			channels: []*channels.Channel{
+				newChannel(1, testToken, partnerA, 0, 1000, channels.StateClosed),
+				newChannel(2, testToken, partnerB, 0, 1000, channels.StateSettled),
+			},
+			targets: []*Target{
+				&Target{TokenAddress: testToken, Ratio: 0.5, Budget: util.NewAmount(1000)},
+			},
+			expectedTopUps: map[int64]int64{},
+		},
+		testcase{
+			name: "uses undeposited connection funds as budget",
+			channels: []*channels.Channel{
+				newChannel(1, testToken, partnerA, 0, 1000, channels.StateOpened),
+			},
+			funds: map[common.Address]int64{testToken: 1500},
+			targets: []*Target{
+				&Target{TokenAddress: testToken, Ratio: 0.5},
+			},
+			expectedTopUps:   map[int64]int64{1: 500},
+			expectedPartial:  []int64{1},
+			expectedRequired: 1000,
+			expectedPlanned:  500,
+		},
+		testcase{
+			name: "no budget without a connection",
+			channels: []*channels.Channel{
+				newChannel(1, testToken, partnerA, 0, 1000, channels.StateOpened),
+			},
+			targets: []*Target{
+				&Target{TokenAddress: testToken, Ratio: 0.5},
+			},
+			expectedTopUps:   map[int64]int64{},
+			expectedRequired: 1000,
+		},
+		testcase{
+			name: "invalid ratio",
+			targets: []*Target{
+				&Target{TokenAddress: testToken, Ratio: 1},
+			},
+			expectedError: errors.New("target ratio 1 of token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8 must be greater than 0 and less than 1"),
+		},
+		testcase{
+			name: "negative budget",
+			targets: []*Target{
+				&Target{TokenAddress: testToken, Ratio: 0.5, Budget: util.NewAmount(-1)},
+			},
+			expectedError: errors.New("budget -1 of token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8 must not be negative"),
+		},
+		testcase{
+			name: "token targeted twice",
+			targets: []*Target{
+				&Target{TokenAddress: testToken, Ratio: 0.5, Budget: util.NewAmount(1)},
+				&Target{TokenAddress: testToken, Ratio: 0.3, Budget: util.NewAmount(1)},
+			},
+			expectedError: errors.New("token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8 is targeted more than once"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				node, _    = newNode(t, tc.channels, tc.funds)
+				rebalancer = newRebalancer(node, nil)
+				topUps     = make(map[int64]int64)
+				partial    = make([]int64, 0)
+			)
+
+			defer node.Close()
+
+			plan, err := rebalancer.Plan(context.Background(), tc.targets)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, plan.Tokens, len(tc.targets))
+
+			for _, topUp := range plan.TopUps() {
+				topUps[topUp.Channel.ChannelIdentifier] = topUp.Amount.Big().Int64()
+
+				assert.Equal(t, topUp.Channel.Balance.Add(topUp.Amount), topUp.Balance)
+				assert.Equal(t, topUp.Channel.TotalDeposit.Add(topUp.Amount), topUp.TotalDeposit)
+
+				if topUp.Partial {
+					partial = append(partial, topUp.Channel.ChannelIdentifier)
+				}
+			}
+
+			if tc.expectedPartial == nil {
+				tc.expectedPartial = []int64{}
+			}
+
+			assert.Equal(t, tc.expectedTopUps, topUps)
+			assert.Equal(t, tc.expectedPartial, partial)
+			assert.Equal(t, util.NewAmount(tc.expectedRequired), plan.Tokens[0].Required)
+			assert.Equal(t, util.NewAmount(tc.expectedPlanned), plan.Tokens[0].Planned)
+			assert.Equal(t, util.NewAmount(tc.expectedRequired-tc.expectedPlanned), plan.Tokens[0].Shortfall())
+		})
+	}
+}
+
+func TestRebalancerRebalance(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		targets = []*Target{
+			&Target{TokenAddress: testToken, Ratio: 0.5, Budget: util.NewAmount(5000)},
+		}
+		channelList = []*channels.Channel{
+			newChannel(1, testToken, partnerA, 100, 1000, channels.StateOpened),
+			newChannel(2, testToken, partnerB, 0, 1000, channels.StateOpened),
+		}
+	)
+
+	totalDeposits := func(t *testing.T, channelClient *channels.Client) []*util.Amount {
+		deposits := make([]*util.Amount, 0)
+
+		for _, partner := range []common.Address{partnerA, partnerB} {
+			channel, err := channelClient.Get(ctx, testToken, partner)
+			require.NoError(t, err)
+
+			deposits = append(deposits, channel.TotalDeposit)
+		}
+
+		return deposits
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		node, channelClient := newNode(t, channelList, nil)
+		defer node.Close()
+
+		result, err := newRebalancer(node, &Options{DryRun: true}).Rebalance(ctx, targets)
+		require.NoError(t, err)
+
+		assert.True(t, result.DryRun)
+		assert.Len(t, result.Plan.TopUps(), 2)
+		assert.Empty(t, result.Channels)
+		assert.Equal(t, []*util.Amount{util.NewAmount(1000), util.NewAmount(1000)}, totalDeposits(t, channelClient))
+	})
+
+	t.Run("deposits up to the planned balances", func(t *testing.T) {
+		node, channelClient := newNode(t, channelList, nil)
+		defer node.Close()
+
+		result, err := newRebalancer(node, nil).Rebalance(ctx, targets)
+		require.NoError(t, err)
+
+		assert.False(t, result.DryRun)
+		require.Len(t, result.Channels, 2)
+		assert.Equal(t, util.NewAmount(1000), result.Channels[0].Balance)
+		assert.Equal(t, util.NewAmount(900), result.Channels[1].Balance)
+		assert.Equal(t, []*util.Amount{util.NewAmount(1800), util.NewAmount(2000)}, totalDeposits(t, channelClient))
+	})
+
+	t.Run("counts deposits made since planning", func(t *testing.T) {
+		node, channelClient := newNode(t, channelList, nil)
+		defer node.Close()
+
+		rebalancer := newRebalancer(node, nil)
+
+		plan, err := rebalancer.Plan(ctx, targets)
+		require.NoError(t, err)
+
+		// the planned total deposit of 2000 is outdated once partner B's channel
+		// was topped up past the planned balance
+		_, err = channelClient.IncreaseDeposit(ctx, testToken, partnerB, util.NewAmount(2500))
+		require.NoError(t, err)
+
+		result, err := rebalancer.Execute(ctx, plan)
+		require.NoError(t, err)
+
+		assert.Len(t, result.Channels, 2)
+		assert.Equal(t, []*util.Amount{util.NewAmount(1800), util.NewAmount(2500)}, totalDeposits(t, channelClient))
+	})
+
+	t.Run("stops at the first failed deposit", func(t *testing.T) {
+		node, channelClient := newNode(t, channelList, nil)
+		defer node.Close()
+
+		rebalancer := newRebalancer(node, nil)
+
+		plan, err := rebalancer.Plan(ctx, targets)
+		require.NoError(t, err)
+
+		// partner B's channel is the most drained and topped up first
+		_, err = channelClient.Close(ctx, testToken, partnerA)
+		require.NoError(t, err)
+
+		result, err := rebalancer.Execute(ctx, plan)
+		assert.EqualError(t, err, "unable to top up channel 1 with partner 0x61C808D82A3Ac53231750daDc13c777b59310bD9: unable to deposit into channel 1 with partner 0x61C808D82A3Ac53231750daDc13c777b59310bD9: channel is closed")
+
+		assert.Len(t, result.Channels, 1)
+	})
+
+	t.Run("unable to list channels", func(t *testing.T) {
+		node, _ := newNode(t, channelList, nil)
+		defer node.Close()
+
+		node.SetStatus("syncing", 10)
+
+		_, err := newRebalancer(node, nil).Rebalance(ctx, targets)
+		require.Error(t, err)
+		assert.True(t, strings.HasPrefix(err.Error(), "unable to list channels of token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8: "), err.Error())
+
+		apiError, ok := util.AsAPIError(err)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusServiceUnavailable, apiError.StatusCode)
+	})
+}
+
+func TestTopUpAmount(t *testing.T) {
+	var (
+		ratio = big.NewRat(1, 3)
+	)
+
+	assert.Equal(t, big.NewInt(0), topUpAmount(ratio, big.NewInt(500), big.NewInt(1000)))
+	assert.Equal(t, big.NewInt(0), topUpAmount(ratio, big.NewInt(1500), big.NewInt(1000)))
+	assert.Equal(t, big.NewInt(500), topUpAmount(ratio, big.NewInt(0), big.NewInt(1000)))
+	assert.Equal(t, big.NewInt(1), topUpAmount(ratio, big.NewInt(0), big.NewInt(1)))
+}
@@ -0,0 +1,37 @@
+package testnet
+
+import (
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/address"
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/connections"
+	"github.com/cpurta/go-raiden-client/tokens"
+)
+
+var (
+	_ Minter            = &Client{}
+	_ tokens.Minter     = &Client{}
+	_ tokens.MintJoiner = &Client{}
+)
+
+// NewClient creates a new client to the testing operations of a Raiden node
+// running in a development environment. This includes Minting test tokens and
+// joining token networks with them.
+func NewClient(config *config.Config, httpClient *http.Client) *Client {
+	var (
+		minter = NewMinter(config, httpClient)
+	)
+
+	return &Client{
+		Minter:     minter,
+		MintJoiner: tokens.NewMintJoiner(minter, address.NewGetter(config, httpClient), tokens.NewGetter(config, httpClient), tokens.NewRegistrar(config, httpClient), connections.NewJoiner(config, httpClient)),
+	}
+}
+
+// Client allows for the testing operations to be performed over HTTP calls to a
+// Raiden node.
+type Client struct {
+	Minter
+	tokens.MintJoiner
+}
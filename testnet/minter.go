@@ -0,0 +1,68 @@
+// Package testnet gives access to the testing endpoints of Raiden nodes, which
+// are only served by nodes running in a development environment and must never
+// be relied on in production.
+package testnet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type mintRequest struct {
+	To    common.Address `json:"to"`
+	Value *util.Amount   `json:"value"`
+}
+
+type mintResponse struct {
+	TransactionHash string `json:"transaction_hash"`
+}
+
+// Minter is an interface to mint test tokens through a Raiden node.
+type Minter interface {
+	Mint(ctx context.Context, tokenAddress, toAddress common.Address, amount *util.Amount) (common.Hash, error)
+}
+
+var _ Minter = &defaultMinter{}
+
+// NewMinter will create a default minter for a configured Raiden node.
+func NewMinter(config *config.Config, httpClient *http.Client) Minter {
+	return &defaultMinter{
+		baseClient: &util.BaseClient{
+			Config:     config,
+			HTTPClient: httpClient,
+		},
+	}
+}
+
+type defaultMinter struct {
+	baseClient *util.BaseClient
+}
+
+// Mint will have the node mint the amount of the token to the given address and
+// return the hash of the mint transaction, which the node waits for to be mined.
+// This only works for test tokens that allow anyone to mint.
+func (minter *defaultMinter) Mint(ctx context.Context, tokenAddress, toAddress common.Address, amount *util.Amount) (common.Hash, error) {
+	var (
+		err          error
+		mintResponse = &mintResponse{}
+		mintRequest  = &mintRequest{
+			To:    toAddress,
+			Value: amount,
+		}
+	)
+
+	if amount.Sign() <= 0 {
+		return common.Hash{}, errors.New("mint amount must be greater than zero")
+	}
+
+	if err = minter.baseClient.Do(ctx, "POST", []string{"_testing", "tokens", tokenAddress.Hex(), "mint"}, mintRequest, mintResponse); err != nil {
+		return common.Hash{}, err
+	}
+
+	return common.HexToHash(mintResponse.TransactionHash), nil
+}
@@ -0,0 +1,151 @@
+package testnet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleMinter() {
+	var (
+		testnetClient *Client
+		config        = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		tokenAddress = common.HexToAddress("0x59105441977ecD9d805A4f5b060E34676F50F806") // Goerli test token
+		toAddress    = common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226")
+		amount       = util.NewAmount(1000000000000000000)
+		hash         common.Hash
+		err          error
+	)
+
+	testnetClient = NewClient(config, http.DefaultClient)
+
+	if hash, err = testnetClient.Mint(context.Background(), tokenAddress, toAddress, amount); err != nil {
+		panic(fmt.Sprintf("unable to mint tokens: %s", err.Error()))
+	}
+
+	fmt.Printf("mint transaction: %s\n", hash.Hex())
+}
+
+func TestMinter(t *testing.T) {
+	var (
+		localhostIP = "[::1]"
+		config      = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		mintURL   = "http://localhost:5001/api/v1/_testing/tokens/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/mint"
+		toAddress = common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226")
+	)
+
+	if os.Getenv("USE_IPV4") != "" {
+		localhostIP = "127.0.0.1"
+	}
+
+	type testcase struct {
+		name             string
+		prepHTTPMock     func(requests *[]*mintRequest)
+		amount           *util.Amount
+		expectedHash     common.Hash
+		expectedRequests []*mintRequest
+		expectedError    error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "successfully minted tokens",
+			prepHTTPMock: func(requests *[]*mintRequest) {
+				httpmock.RegisterResponder("POST", mintURL, func(request *http.Request) (*http.Response, error) {
+					mintRequest := &mintRequest{}
+
+					if err := json.NewDecoder(request.Body).Decode(mintRequest); err != nil {
+						return nil, err
+					}
+
+					*requests = append(*requests, mintRequest)
+
+					return httpmock.NewStringResponse(
+						http.StatusOK,
+						`{"transaction_hash":"0x90896386c82e8c9a9a2a2c0b0a1a8e6b6c1f1e4a7c8e1e5c2f3b0d9a1f6e2d4c"}`,
+					), nil
+				})
+			},
+			amount:           util.NewAmount(1000),
+			expectedHash:     common.HexToHash("0x90896386c82e8c9a9a2a2c0b0a1a8e6b6c1f1e4a7c8e1e5c2f3b0d9a1f6e2d4c"),
+			expectedRequests: []*mintRequest{&mintRequest{To: toAddress, Value: util.NewAmount(1000)}},
+		},
+		testcase{
+			name: "testing endpoints not served",
+			prepHTTPMock: func(requests *[]*mintRequest) {
+				httpmock.RegisterResponder(
+					"POST",
+					mintURL,
+					httpmock.NewStringResponder(
+						http.StatusNotFound,
+						``,
+					),
+				)
+			},
+			amount:        util.NewAmount(1000),
+			expectedError: &util.APIError{StatusCode: http.StatusNotFound, Method: "POST", URL: mintURL},
+		},
+		testcase{
+			name: "zero amount",
+			prepHTTPMock: func(requests *[]*mintRequest) {
+			},
+			amount:        util.NewAmount(0),
+			expectedError: errors.New("mint amount must be greater than zero"),
+		},
+		testcase{
+			name: "unable to make http request",
+			prepHTTPMock: func(requests *[]*mintRequest) {
+				httpmock.Deactivate()
+			},
+			amount:        util.NewAmount(1000),
+			expectedError: fmt.Errorf("Post %s: dial tcp %s:5001: connect: connection refused", mintURL, localhostIP),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				err          error
+				hash         common.Hash
+				requests     = make([]*mintRequest, 0)
+				tokenAddress = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+
+				minter = NewMinter(config, http.DefaultClient)
+				ctx    = context.Background()
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			tc.prepHTTPMock(&requests)
+
+			hash, err = minter.Mint(ctx, tokenAddress, toAddress, tc.amount)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedHash, hash)
+			assert.Equal(t, tc.expectedRequests, requests)
+		})
+	}
+}
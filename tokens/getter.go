@@ -2,10 +2,7 @@ package tokens
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
-	"net/url"
 
 	"github.com/cpurta/go-raiden-client/config"
 	"github.com/cpurta/go-raiden-client/util"
@@ -35,36 +32,16 @@ type defaultGetter struct {
 	baseClient *util.BaseClient
 }
 
-// List will return the associated Ethereum address to the Raiden node configured
-// in the Getter config.
+// Get will return the token network address for the given token address on the
+// Raiden node configured in the Getter config.
 func (Getter *defaultGetter) Get(ctx context.Context, tokenAddress common.Address) (common.Address, error) {
 	var (
 		err            error
 		address        string
 		networkAddress = common.Address{}
-
-		requestURL *url.URL
-		request    *http.Request
-		response   *http.Response
 	)
 
-	if requestURL, err = Getter.getRequestURL(tokenAddress); err != nil {
-		return networkAddress, err
-	}
-
-	if request, err = http.NewRequest("GET", requestURL.String(), nil); err != nil {
-		return networkAddress, err
-	}
-
-	request = request.WithContext(ctx)
-
-	if response, err = Getter.baseClient.HTTPClient.Do(request); err != nil {
-		return networkAddress, err
-	}
-
-	defer response.Body.Close()
-
-	if err = json.NewDecoder(response.Body).Decode(&address); err != nil {
+	if err = Getter.baseClient.Do(ctx, "GET", []string{"tokens", tokenAddress.Hex()}, nil, &address); err != nil {
 		return networkAddress, err
 	}
 
@@ -72,17 +49,3 @@ func (Getter *defaultGetter) Get(ctx context.Context, tokenAddress common.Addres
 
 	return networkAddress, nil
 }
-
-func (Getter *defaultGetter) getRequestURL(address common.Address) (*url.URL, error) {
-	var (
-		err        error
-		endpoint   = fmt.Sprintf("%s/api/%s/tokens/%s", Getter.baseClient.Config.Host, Getter.baseClient.Config.APIVersion, address.Hex())
-		requestURL *url.URL
-	)
-
-	if requestURL, err = url.Parse(endpoint); err != nil {
-		return nil, err
-	}
-
-	return requestURL, nil
-}
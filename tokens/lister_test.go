@@ -2,13 +2,13 @@ package tokens
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"testing"
 
 	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -86,7 +86,7 @@ func TestLister(t *testing.T) {
 					),
 				)
 			},
-			expectedError:     errors.New("EOF"),
+			expectedError:     &util.APIError{StatusCode: http.StatusInternalServerError, Method: "GET", URL: "http://localhost:5001/api/v1/tokens"},
 			expectedAddresses: []common.Address{},
 		},
 		testcase{
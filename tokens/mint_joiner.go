@@ -0,0 +1,89 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cpurta/go-raiden-client/address"
+	"github.com/cpurta/go-raiden-client/connections"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Minter is an interface to mint test tokens, as done by the testnet client of
+// a Raiden node running in a development environment.
+type Minter interface {
+	Mint(ctx context.Context, tokenAddress, toAddress common.Address, amount *util.Amount) (common.Hash, error)
+}
+
+// MintJoiner is an interface to fund a Raiden node with test tokens and join the
+// token network with them in one step, for integration environments.
+type MintJoiner interface {
+	MintAndJoin(ctx context.Context, tokenAddress common.Address, funds *util.Amount) (common.Address, error)
+}
+
+var _ MintJoiner = &defaultMintJoiner{}
+
+// NewMintJoiner will create a default mint joiner that registers the token
+// network if the token getter does not know it, mints with the minter to the
+// address of the node returned by the address getter and joins the token network
+// with the connections joiner.
+func NewMintJoiner(minter Minter, addressGetter address.Getter, getter Getter, registrar Registrar, joiner connections.Joiner) MintJoiner {
+	return &defaultMintJoiner{
+		minter:        minter,
+		addressGetter: addressGetter,
+		getter:        getter,
+		registrar:     registrar,
+		joiner:        joiner,
+	}
+}
+
+type defaultMintJoiner struct {
+	minter        Minter
+	addressGetter address.Getter
+	getter        Getter
+	registrar     Registrar
+	joiner        connections.Joiner
+}
+
+// MintAndJoin will register the token network if it does not exist yet, mint
+// funds of the token to the node and join the token network with the minted
+// funds. The token network address is returned. No funds are minted for a token
+// that can not be registered. Steps that succeeded are not undone if a later one
+// fails, so a retry after a failed join mints the funds again.
+func (mintJoiner *defaultMintJoiner) MintAndJoin(ctx context.Context, tokenAddress common.Address, funds *util.Amount) (common.Address, error) {
+	var (
+		err                 error
+		nodeAddress         common.Address
+		tokenNetworkAddress common.Address
+	)
+
+	if funds.Sign() <= 0 {
+		return common.Address{}, errors.New("funds must be greater than zero")
+	}
+
+	if nodeAddress, err = mintJoiner.addressGetter.Get(ctx); err != nil {
+		return common.Address{}, util.WrapError(err, "unable to get node address")
+	}
+
+	tokenNetworkAddress, err = mintJoiner.getter.Get(ctx, tokenAddress)
+
+	switch {
+	case util.IsNotFound(err):
+		if tokenNetworkAddress, err = mintJoiner.registrar.Register(ctx, tokenAddress); err != nil {
+			return common.Address{}, util.WrapError(err, "unable to register token %s", tokenAddress.Hex())
+		}
+	case err != nil:
+		return common.Address{}, util.WrapError(err, "unable to get token network of token %s", tokenAddress.Hex())
+	}
+
+	if _, err = mintJoiner.minter.Mint(ctx, tokenAddress, nodeAddress, funds); err != nil {
+		return common.Address{}, util.WrapError(err, "unable to mint %s of token %s", funds, tokenAddress.Hex())
+	}
+
+	if err = mintJoiner.joiner.Join(ctx, tokenAddress, funds); err != nil {
+		return common.Address{}, util.WrapError(err, "unable to join token network of token %s", tokenAddress.Hex())
+	}
+
+	return tokenNetworkAddress, nil
+}
@@ -0,0 +1,217 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/address"
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/connections"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testMinter mints through the testing endpoint of the node like the testnet
+// minter, which can not be imported here as the testnet package imports tokens.
+type testMinter struct {
+	baseClient *util.BaseClient
+}
+
+func (minter *testMinter) Mint(ctx context.Context, tokenAddress, toAddress common.Address, amount *util.Amount) (common.Hash, error) {
+	var (
+		mintResponse = &struct {
+			TransactionHash string `json:"transaction_hash"`
+		}{}
+		mintRequest = map[string]interface{}{
+			"to":    toAddress,
+			"value": amount,
+		}
+	)
+
+	if err := minter.baseClient.Do(ctx, "POST", []string{"_testing", "tokens", tokenAddress.Hex(), "mint"}, mintRequest, mintResponse); err != nil {
+		return common.Hash{}, err
+	}
+
+	return common.HexToHash(mintResponse.TransactionHash), nil
+}
+
+func ExampleMintJoiner() {
+	var (
+		config = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		tokenAddress        = common.HexToAddress("0x59105441977ecD9d805A4f5b060E34676F50F806") // Goerli test token
+		tokenClient         = NewClient(config, http.DefaultClient)
+		minter              Minter // the minter of a testnet client, e.g. testnet.NewMinter(config, http.DefaultClient)
+		tokenNetworkAddress common.Address
+		err                 error
+	)
+
+	mintJoiner := NewMintJoiner(minter, address.NewGetter(config, http.DefaultClient), tokenClient, tokenClient, connections.NewJoiner(config, http.DefaultClient))
+
+	if tokenNetworkAddress, err = mintJoiner.MintAndJoin(context.Background(), tokenAddress, util.NewAmount(1000000000000000000)); err != nil {
+		panic(fmt.Sprintf("unable to mint and join token network: %s", err.Error()))
+	}
+
+	fmt.Printf("joined token network %s\n", tokenNetworkAddress.Hex())
+}
+
+func TestMintJoiner(t *testing.T) {
+	var (
+		config = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		addressURL    = "http://localhost:5001/api/v1/address"
+		mintURL       = "http://localhost:5001/api/v1/_testing/tokens/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8/mint"
+		tokenURL      = "http://localhost:5001/api/v1/tokens/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"
+		connectionURL = "http://localhost:5001/api/v1/connections/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"
+		networkJSON   = `"0x61C808D82A3Ac53231750daDc13c777b59310bD9"`
+	)
+
+	// register records every call so the order of the steps can be asserted
+	register := func(calls *[]string, method, url string, status int, body string) {
+		httpmock.RegisterResponder(method, url, func(request *http.Request) (*http.Response, error) {
+			*calls = append(*calls, method+" "+url)
+
+			response := httpmock.NewStringResponse(status, body)
+			response.Request = request
+
+			return response, nil
+		})
+	}
+
+	registerAddress := func(calls *[]string) {
+		register(calls, "GET", addressURL, http.StatusOK, `{"our_address":"0x2a65Aca4D5fC5B5C859090a6c34d164135398226"}`)
+	}
+
+	registerMint := func(calls *[]string) {
+		register(calls, "POST", mintURL, http.StatusOK, `{"transaction_hash":"0x90896386c82e8c9a9a2a2c0b0a1a8e6b6c1f1e4a7c8e1e5c2f3b0d9a1f6e2d4c"}`)
+	}
+
+	type testcase struct {
+		name          string
+		prepHTTPMock  func(calls *[]string)
+		funds         *util.Amount
+		expectedCalls []string
+		expectedError error
+		// expectedStatus is the status code of the node error kept by the error
+		expectedStatus int
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "token network already registered",
+			prepHTTPMock: func(calls *[]string) {
+				registerAddress(calls)
+				registerMint(calls)
+				register(calls, "GET", tokenURL, http.StatusOK, networkJSON)
+				register(calls, "PUT", connectionURL, http.StatusNoContent, ``)
+			},
+			funds:         util.NewAmount(1000),
+			expectedCalls: []string{"GET " + addressURL, "GET " + tokenURL, "POST " + mintURL, "PUT " + connectionURL},
+		},
+		testcase{
+			name: "token network registered first",
+			prepHTTPMock: func(calls *[]string) {
+				registerAddress(calls)
+				registerMint(calls)
+				register(calls, "GET", tokenURL, http.StatusNotFound, ``)
+				register(calls, "PUT", tokenURL, http.StatusCreated, `{"token_network_address":"0x61C808D82A3Ac53231750daDc13c777b59310bD9"}`)
+				register(calls, "PUT", connectionURL, http.StatusNoContent, ``)
+			},
+			funds:         util.NewAmount(1000),
+			expectedCalls: []string{"GET " + addressURL, "GET " + tokenURL, "PUT " + tokenURL, "POST " + mintURL, "PUT " + connectionURL},
+		},
+		testcase{
+			name: "unable to register token network",
+			prepHTTPMock: func(calls *[]string) {
+				registerAddress(calls)
+				registerMint(calls)
+				register(calls, "GET", tokenURL, http.StatusNotFound, ``)
+				register(calls, "PUT", tokenURL, http.StatusConflict, `{"errors":"Token network for given token address already exists"}`)
+			},
+			funds:          util.NewAmount(1000),
+			expectedCalls:  []string{"GET " + addressURL, "GET " + tokenURL, "PUT " + tokenURL},
+			expectedError:  errors.New("unable to register token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8: PUT " + tokenURL + ": 409 Conflict: Token network for given token address already exists"),
+			expectedStatus: http.StatusConflict,
+		},
+		testcase{
+			name: "unable to mint",
+			prepHTTPMock: func(calls *[]string) {
+				registerAddress(calls)
+				register(calls, "GET", tokenURL, http.StatusOK, networkJSON)
+				register(calls, "POST", mintURL, http.StatusNotFound, ``)
+			},
+			funds:          util.NewAmount(1000),
+			expectedCalls:  []string{"GET " + addressURL, "GET " + tokenURL, "POST " + mintURL},
+			expectedError:  errors.New("unable to mint 1000 of token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8: POST " + mintURL + ": 404 Not Found"),
+			expectedStatus: http.StatusNotFound,
+		},
+		testcase{
+			name: "unable to join",
+			prepHTTPMock: func(calls *[]string) {
+				registerAddress(calls)
+				registerMint(calls)
+				register(calls, "GET", tokenURL, http.StatusOK, networkJSON)
+				register(calls, "PUT", connectionURL, http.StatusPaymentRequired, `{"errors":"Not enough balance to join the network"}`)
+			},
+			funds:          util.NewAmount(1000),
+			expectedCalls:  []string{"GET " + addressURL, "GET " + tokenURL, "POST " + mintURL, "PUT " + connectionURL},
+			expectedError:  errors.New("unable to join token network of token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8: PUT " + connectionURL + ": 402 Payment Required: Not enough balance to join the network"),
+			expectedStatus: http.StatusPaymentRequired,
+		},
+		testcase{
+			name: "zero funds",
+			prepHTTPMock: func(calls *[]string) {
+			},
+			funds:         util.NewAmount(0),
+			expectedCalls: []string{},
+			expectedError: errors.New("funds must be greater than zero"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				calls        = make([]string, 0)
+				tokenAddress = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+				tokenClient  = NewClient(config, http.DefaultClient)
+				minter       = &testMinter{baseClient: &util.BaseClient{Config: config, HTTPClient: http.DefaultClient}}
+
+				mintJoiner = NewMintJoiner(minter, address.NewGetter(config, http.DefaultClient), tokenClient, tokenClient, connections.NewJoiner(config, http.DefaultClient))
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			tc.prepHTTPMock(&calls)
+
+			tokenNetworkAddress, err := mintJoiner.MintAndJoin(context.Background(), tokenAddress, tc.funds)
+
+			assert.Equal(t, tc.expectedCalls, calls)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+
+				if tc.expectedStatus != 0 {
+					apiError, ok := util.AsAPIError(err)
+					require.True(t, ok)
+					assert.Equal(t, tc.expectedStatus, apiError.StatusCode)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, common.HexToAddress("0x61C808D82A3Ac53231750daDc13c777b59310bD9"), tokenNetworkAddress)
+		})
+	}
+}
@@ -2,10 +2,7 @@ package tokens
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
-	"net/url"
 
 	"github.com/cpurta/go-raiden-client/config"
 	"github.com/cpurta/go-raiden-client/util"
@@ -41,45 +38,11 @@ func (lister *defaultPartnerLister) ListPartners(ctx context.Context, tokenAddre
 	var (
 		err      error
 		partners = make([]*Partner, 0)
-
-		requestURL *url.URL
-		request    *http.Request
-		response   *http.Response
 	)
 
-	if requestURL, err = lister.getRequestURL(tokenAddress); err != nil {
-		return nil, err
-	}
-
-	if request, err = http.NewRequest("GET", requestURL.String(), nil); err != nil {
-		return nil, err
-	}
-
-	request = request.WithContext(ctx)
-
-	if response, err = lister.baseClient.HTTPClient.Do(request); err != nil {
-		return nil, err
-	}
-
-	defer response.Body.Close()
-
-	if err = json.NewDecoder(response.Body).Decode(&partners); err != nil {
+	if err = lister.baseClient.Do(ctx, "GET", []string{"tokens", tokenAddress.Hex(), "partners"}, nil, &partners); err != nil {
 		return nil, err
 	}
 
 	return partners, nil
 }
-
-func (lister *defaultPartnerLister) getRequestURL(tokenAddress common.Address) (*url.URL, error) {
-	var (
-		err        error
-		endpoint   = fmt.Sprintf("%s/api/%s/tokens/%s/partners", lister.baseClient.Config.Host, lister.baseClient.Config.APIVersion, tokenAddress.Hex())
-		requestURL *url.URL
-	)
-
-	if requestURL, err = url.Parse(endpoint); err != nil {
-		return nil, err
-	}
-
-	return requestURL, nil
-}
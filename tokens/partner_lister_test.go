@@ -2,13 +2,13 @@ package tokens
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"testing"
 
 	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -89,7 +89,7 @@ func TestPartnerLister(t *testing.T) {
 					),
 				)
 			},
-			expectedError:    errors.New("EOF"),
+			expectedError:    &util.APIError{StatusCode: http.StatusInternalServerError, Method: "GET", URL: "http://localhost:5001/api/v1/tokens/0x61bB630D3B2e8eda0FC1d50F9f958eC02e3969F6/partners"},
 			expectedPartners: []*Partner{},
 		},
 		testcase{
@@ -2,10 +2,7 @@ package tokens
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
-	"net/url"
 
 	"github.com/cpurta/go-raiden-client/config"
 	"github.com/cpurta/go-raiden-client/util"
@@ -39,36 +36,16 @@ type defaultRegistrar struct {
 	baseClient *util.BaseClient
 }
 
-// List will return the associated Ethereum address to the Raiden node configured
-// in the Lister config.
+// Register will register a new token network for the given token address on the
+// Raiden node and return the address of the newly created token network.
 func (lister *defaultRegistrar) Register(ctx context.Context, tokenAddress common.Address) (common.Address, error) {
 	var (
 		err              error
-		registerResponse *registerTokenResponse
+		registerResponse = &registerTokenResponse{}
 		networkAddress   = common.Address{}
-
-		requestURL *url.URL
-		request    *http.Request
-		response   *http.Response
 	)
 
-	if requestURL, err = lister.getRequestURL(tokenAddress); err != nil {
-		return networkAddress, err
-	}
-
-	if request, err = http.NewRequest("PUT", requestURL.String(), nil); err != nil {
-		return networkAddress, err
-	}
-
-	request = request.WithContext(ctx)
-
-	if response, err = lister.baseClient.HTTPClient.Do(request); err != nil {
-		return networkAddress, err
-	}
-
-	defer response.Body.Close()
-
-	if err = json.NewDecoder(response.Body).Decode(&registerResponse); err != nil {
+	if err = lister.baseClient.Do(ctx, "PUT", []string{"tokens", tokenAddress.Hex()}, nil, registerResponse); err != nil {
 		return networkAddress, err
 	}
 
@@ -76,17 +53,3 @@ func (lister *defaultRegistrar) Register(ctx context.Context, tokenAddress commo
 
 	return networkAddress, nil
 }
-
-func (lister *defaultRegistrar) getRequestURL(address common.Address) (*url.URL, error) {
-	var (
-		err        error
-		endpoint   = fmt.Sprintf("%s/api/%s/tokens/%s", lister.baseClient.Config.Host, lister.baseClient.Config.APIVersion, address.Hex())
-		requestURL *url.URL
-	)
-
-	if requestURL, err = url.Parse(endpoint); err != nil {
-		return nil, err
-	}
-
-	return requestURL, nil
-}
@@ -0,0 +1,220 @@
+package tokens
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Metadata describes how amounts of a token are presented to users. Amounts
+// returned by a Raiden node are always in base units, which are scaled by
+// 10^Decimals to get whole tokens.
+type Metadata struct {
+	Address  common.Address `json:"address"`
+	Symbol   string         `json:"symbol"`
+	Name     string         `json:"name"`
+	Decimals int            `json:"decimals"`
+}
+
+type tokenList struct {
+	Tokens []*Metadata `json:"tokens"`
+}
+
+// Registry holds the metadata of tokens keyed by token address in order to parse
+// and format amounts of any sub-client. A Registry is safe for concurrent use.
+type Registry struct {
+	mutex     sync.RWMutex
+	byAddress map[common.Address]*Metadata
+	bySymbol  map[string]*Metadata
+}
+
+// NewRegistry creates an empty token registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		byAddress: make(map[common.Address]*Metadata),
+		bySymbol:  make(map[string]*Metadata),
+	}
+}
+
+// LoadRegistry creates a token registry from a JSON token list file, see
+// ReadTokenList.
+func LoadRegistry(path string) (*Registry, error) {
+	var (
+		err      error
+		file     *os.File
+		registry = NewRegistry()
+	)
+
+	if file, err = os.Open(path); err != nil {
+		return nil, fmt.Errorf("unable to open token list: %s", err.Error())
+	}
+
+	defer file.Close()
+
+	if err = registry.ReadTokenList(file); err != nil {
+		return nil, fmt.Errorf("unable to read token list %s: %s", path, err.Error())
+	}
+
+	return registry, nil
+}
+
+// Register adds the metadata of a token. Symbols are matched case-insensitively
+// and must be unique, as must be addresses.
+func (registry *Registry) Register(metadata *Metadata) error {
+	var (
+		symbol = strings.ToUpper(metadata.Symbol)
+	)
+
+	if symbol == "" || strings.ContainsAny(symbol, " \t") {
+		return fmt.Errorf("invalid symbol %q of token %s", metadata.Symbol, metadata.Address.Hex())
+	}
+
+	if metadata.Decimals < 0 || metadata.Decimals > util.MaxDecimals {
+		return fmt.Errorf("invalid number of decimals %d of token %s", metadata.Decimals, metadata.Address.Hex())
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if _, ok := registry.byAddress[metadata.Address]; ok {
+		return fmt.Errorf("token %s is already registered", metadata.Address.Hex())
+	}
+
+	if other, ok := registry.bySymbol[symbol]; ok {
+		return fmt.Errorf("symbol %s of token %s is already used by token %s", metadata.Symbol, metadata.Address.Hex(), other.Address.Hex())
+	}
+
+	registry.byAddress[metadata.Address] = metadata
+	registry.bySymbol[symbol] = metadata
+
+	return nil
+}
+
+// ReadTokenList registers the tokens of a JSON token list, which is either an
+// object with a "tokens" array as used by common token lists or a bare array of
+// tokens with "address", "symbol", "name" and "decimals" fields.
+func (registry *Registry) ReadTokenList(reader io.Reader) error {
+	var (
+		err  error
+		raw  json.RawMessage
+		list = &tokenList{}
+	)
+
+	if err = json.NewDecoder(reader).Decode(&raw); err != nil {
+		return err
+	}
+
+	if trimmed := strings.TrimSpace(string(raw)); strings.HasPrefix(trimmed, "[") {
+		err = json.Unmarshal(raw, &list.Tokens)
+	} else {
+		err = json.Unmarshal(raw, list)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, metadata := range list.Tokens {
+		if err = registry.Register(metadata); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Lookup returns the metadata of the token with the given address.
+func (registry *Registry) Lookup(tokenAddress common.Address) (*Metadata, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	metadata, ok := registry.byAddress[tokenAddress]
+
+	return metadata, ok
+}
+
+// LookupSymbol returns the metadata of the token with the given symbol.
+func (registry *Registry) LookupSymbol(symbol string) (*Metadata, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	metadata, ok := registry.bySymbol[strings.ToUpper(symbol)]
+
+	return metadata, ok
+}
+
+// Tokens returns the metadata of all registered tokens sorted by symbol.
+func (registry *Registry) Tokens() []*Metadata {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	tokens := make([]*Metadata, 0, len(registry.byAddress))
+
+	for _, metadata := range registry.byAddress {
+		tokens = append(tokens, metadata)
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return strings.ToUpper(tokens[i].Symbol) < strings.ToUpper(tokens[j].Symbol)
+	})
+
+	return tokens
+}
+
+// Parse parses a human readable amount followed by a token symbol, e.g. "12.5
+// DAI", and returns the token's address and the amount in base units.
+func (registry *Registry) Parse(value string) (common.Address, *util.Amount, error) {
+	var (
+		fields = strings.Fields(value)
+	)
+
+	if len(fields) != 2 {
+		return common.Address{}, nil, fmt.Errorf("invalid token amount %q, expected an amount and a symbol", value)
+	}
+
+	metadata, ok := registry.LookupSymbol(fields[1])
+
+	if !ok {
+		return common.Address{}, nil, fmt.Errorf("unknown token symbol %q", fields[1])
+	}
+
+	amount, err := util.ParseUnits(fields[0], metadata.Decimals)
+
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	return metadata.Address, amount, nil
+}
+
+// ParseAmount parses a human readable amount of the token with the given address,
+// e.g. "12.5", into base units.
+func (registry *Registry) ParseAmount(tokenAddress common.Address, value string) (*util.Amount, error) {
+	metadata, ok := registry.Lookup(tokenAddress)
+
+	if !ok {
+		return nil, fmt.Errorf("unknown token %s", tokenAddress.Hex())
+	}
+
+	return util.ParseUnits(value, metadata.Decimals)
+}
+
+// Format formats an amount in base units of the token with the given address for
+// display, e.g. "12.5 DAI". Amounts of unknown tokens are formatted in base units
+// followed by the token address.
+func (registry *Registry) Format(tokenAddress common.Address, amount *util.Amount) string {
+	metadata, ok := registry.Lookup(tokenAddress)
+
+	if !ok {
+		return fmt.Sprintf("%s %s", amount.String(), tokenAddress.Hex())
+	}
+
+	return fmt.Sprintf("%s %s", util.FormatUnits(amount, metadata.Decimals), metadata.Symbol)
+}
@@ -0,0 +1,204 @@
+package tokens
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleRegistry() {
+	var (
+		registry     = NewRegistry()
+		tokenAddress = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359") // DAI Stablecoin
+	)
+
+	if err := registry.Register(&Metadata{Address: tokenAddress, Symbol: "DAI", Name: "Dai Stablecoin", Decimals: 18}); err != nil {
+		panic(fmt.Sprintf("unable to register token: %s", err.Error()))
+	}
+
+	_, amount, err := registry.Parse("12.5 DAI")
+
+	if err != nil {
+		panic(fmt.Sprintf("unable to parse amount: %s", err.Error()))
+	}
+
+	fmt.Println(amount.String())
+	fmt.Println(registry.Format(tokenAddress, amount))
+	// Output:
+	// 12500000000000000000
+	// 12.5 DAI
+}
+
+func TestRegistry(t *testing.T) {
+	var (
+		daiAddress  = common.HexToAddress("0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359")
+		usdcAddress = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+		unknown     = common.HexToAddress("0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+		dai         = &Metadata{Address: daiAddress, Symbol: "DAI", Name: "Dai Stablecoin", Decimals: 18}
+		usdc        = &Metadata{Address: usdcAddress, Symbol: "USDC", Name: "USD Coin", Decimals: 6}
+		registry    = NewRegistry()
+		oneDAI, _   = new(big.Int).SetString("1000000000000000000", 10)
+	)
+
+	require.NoError(t, registry.Register(dai))
+	require.NoError(t, registry.Register(usdc))
+
+	assert.EqualError(t, registry.Register(dai), "token 0x89d24A6b4CcB1B6fAA2625fE562bDD9a23260359 is already registered")
+	assert.EqualError(t, registry.Register(&Metadata{Address: unknown, Symbol: "dai", Decimals: 18}), "symbol dai of token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8 is already used by token 0x89d24A6b4CcB1B6fAA2625fE562bDD9a23260359")
+	assert.EqualError(t, registry.Register(&Metadata{Address: unknown, Symbol: "", Decimals: 18}), `invalid symbol "" of token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8`)
+	assert.EqualError(t, registry.Register(&Metadata{Address: unknown, Symbol: "TKN", Decimals: -1}), "invalid number of decimals -1 of token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+
+	metadata, ok := registry.LookupSymbol("usdc")
+	assert.True(t, ok)
+	assert.Equal(t, usdc, metadata)
+
+	assert.Equal(t, []*Metadata{dai, usdc}, registry.Tokens())
+
+	type testcase struct {
+		name            string
+		value           string
+		expectedAddress common.Address
+		expectedAmount  *util.Amount
+		expectedError   error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:            "amount with symbol",
+			value:           "1 DAI",
+			expectedAddress: daiAddress,
+			expectedAmount:  util.NewAmountFromBig(oneDAI),
+		},
+		testcase{
+			name:            "lower case symbol",
+			value:           " 2.5  usdc ",
+			expectedAddress: usdcAddress,
+			expectedAmount:  util.NewAmount(2500000),
+		},
+		testcase{
+			name:          "too many decimals",
+			value:         "0.0000001 USDC",
+			expectedError: errors.New(`amount "0.0000001" has more than 6 decimals`),
+		},
+		testcase{
+			name:          "unknown symbol",
+			value:         "1 WETH",
+			expectedError: errors.New(`unknown token symbol "WETH"`),
+		},
+		testcase{
+			name:          "missing symbol",
+			value:         "12.5",
+			expectedError: errors.New(`invalid token amount "12.5", expected an amount and a symbol`),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokenAddress, amount, err := registry.Parse(tc.value)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAddress, tokenAddress)
+			assert.Equal(t, tc.expectedAmount, amount)
+		})
+	}
+
+	amount, err := registry.ParseAmount(usdcAddress, "0.5")
+	require.NoError(t, err)
+	assert.Equal(t, util.NewAmount(500000), amount)
+
+	_, err = registry.ParseAmount(unknown, "0.5")
+	assert.EqualError(t, err, "unknown token 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8")
+
+	assert.Equal(t, "0.5 USDC", registry.Format(usdcAddress, util.NewAmount(500000)))
+	assert.Equal(t, "500000 0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8", registry.Format(unknown, util.NewAmount(500000)))
+}
+
+func TestRegistryReadTokenList(t *testing.T) {
+	type testcase struct {
+		name            string
+		json            string
+		expectedSymbols []string
+		expectedError   error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:            "token list object",
+			json:            `{"name":"Raiden Tokens","tokens":[{"chainId":1,"address":"0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359","symbol":"DAI","name":"Dai Stablecoin","decimals":18}]}`,
+			expectedSymbols: []string{"DAI"},
+		},
+		testcase{
+			name:            "token array",
+			json:            `[{"address":"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48","symbol":"USDC","name":"USD Coin","decimals":6},{"address":"0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359","symbol":"DAI","name":"Dai Stablecoin","decimals":18}]`,
+			expectedSymbols: []string{"DAI", "USDC"},
+		},
+		testcase{
+			name:          "duplicate symbol",
+			json:          `[{"address":"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48","symbol":"USDC","decimals":6},{"address":"0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359","symbol":"USDC","decimals":18}]`,
+			expectedError: errors.New("symbol USDC of token 0x89d24A6b4CcB1B6fAA2625fE562bDD9a23260359 is already used by token 0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"),
+		},
+		testcase{
+			name:          "invalid json",
+			json:          `{"tokens":`,
+			expectedError: errors.New("unexpected EOF"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := NewRegistry()
+
+			err := registry.ReadTokenList(strings.NewReader(tc.json))
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+
+			symbols := make([]string, 0)
+
+			for _, metadata := range registry.Tokens() {
+				symbols = append(symbols, metadata.Symbol)
+			}
+
+			assert.Equal(t, tc.expectedSymbols, symbols)
+		})
+	}
+}
+
+func TestLoadRegistry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tokens")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "tokens.json")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`[{"address":"0x89d24a6b4ccb1b6faa2625fe562bdd9a23260359","symbol":"DAI","decimals":18}]`), 0600))
+
+	registry, err := LoadRegistry(path)
+	require.NoError(t, err)
+
+	_, ok := registry.LookupSymbol("DAI")
+	assert.True(t, ok)
+
+	_, err = LoadRegistry(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package userdeposit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cpurta/go-raiden-client/util"
+)
+
+// LowBalanceError is returned by a BalanceChecker if the effective balance of the
+// node in the UserDeposit contract is below the minimum.
+type LowBalanceError struct {
+	EffectiveBalance *util.Amount
+	Minimum          *util.Amount
+}
+
+func (err *LowBalanceError) Error() string {
+	return fmt.Sprintf("user deposit balance %s is below the minimum of %s", err.EffectiveBalance, err.Minimum)
+}
+
+// BalanceChecker is an interface to check that the node can still pay monitoring
+// and pathfinding services from its user deposit. Its CheckBalance method has the
+// signature of a health check, func(context.Context) error, so it can be
+// registered with a health check endpoint directly.
+type BalanceChecker interface {
+	CheckBalance(ctx context.Context) error
+}
+
+var _ BalanceChecker = &defaultBalanceChecker{}
+
+// NewBalanceChecker will create a default balance checker that gets the user
+// deposit with the getter and requires an effective balance of at least minimum.
+func NewBalanceChecker(getter Getter, minimum *util.Amount) BalanceChecker {
+	return &defaultBalanceChecker{
+		getter:  getter,
+		minimum: minimum,
+	}
+}
+
+type defaultBalanceChecker struct {
+	getter  Getter
+	minimum *util.Amount
+}
+
+// CheckBalance will return a *LowBalanceError if the effective balance, which
+// excludes planned withdrawals, is below the minimum, or the error of getting the
+// user deposit.
+func (checker *defaultBalanceChecker) CheckBalance(ctx context.Context) error {
+	userDeposit, err := checker.getter.Get(ctx)
+
+	if err != nil {
+		return fmt.Errorf("unable to get user deposit: %s", err.Error())
+	}
+
+	if userDeposit.EffectiveBalance.Cmp(checker.minimum) < 0 {
+		return &LowBalanceError{
+			EffectiveBalance: userDeposit.EffectiveBalance,
+			Minimum:          checker.minimum,
+		}
+	}
+
+	return nil
+}
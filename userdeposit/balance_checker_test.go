@@ -0,0 +1,93 @@
+package userdeposit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleBalanceChecker() {
+	var (
+		config = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		minimum = util.NewAmount(1000000000000000000)
+	)
+
+	checker := NewBalanceChecker(NewGetter(config, http.DefaultClient), minimum)
+
+	if err := checker.CheckBalance(context.Background()); err != nil {
+		if lowBalance, ok := err.(*LowBalanceError); ok {
+			log.Printf("top up the user deposit by at least %s\n", lowBalance.Minimum.Sub(lowBalance.EffectiveBalance))
+			return
+		}
+
+		panic(fmt.Sprintf("unable to check user deposit: %s", err.Error()))
+	}
+}
+
+type fakeGetter struct {
+	userDeposit *UserDeposit
+	err         error
+}
+
+func (getter *fakeGetter) Get(ctx context.Context) (*UserDeposit, error) {
+	return getter.userDeposit, getter.err
+}
+
+func TestBalanceChecker(t *testing.T) {
+	type testcase struct {
+		name          string
+		getter        *fakeGetter
+		expectedError error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:   "balance above minimum",
+			getter: &fakeGetter{userDeposit: &UserDeposit{Balance: util.NewAmount(200), EffectiveBalance: util.NewAmount(150)}},
+		},
+		testcase{
+			name:   "balance at minimum",
+			getter: &fakeGetter{userDeposit: &UserDeposit{Balance: util.NewAmount(100), EffectiveBalance: util.NewAmount(100)}},
+		},
+		testcase{
+			name:          "planned withdraw lowers effective balance",
+			getter:        &fakeGetter{userDeposit: &UserDeposit{Balance: util.NewAmount(200), EffectiveBalance: util.NewAmount(50)}},
+			expectedError: &LowBalanceError{EffectiveBalance: util.NewAmount(50), Minimum: util.NewAmount(100)},
+		},
+		testcase{
+			name:          "unable to get user deposit",
+			getter:        &fakeGetter{err: errors.New("connection refused")},
+			expectedError: errors.New("unable to get user deposit: connection refused"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewBalanceChecker(tc.getter, util.NewAmount(100)).CheckBalance(context.Background())
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+
+				if _, ok := tc.expectedError.(*LowBalanceError); ok {
+					assert.Equal(t, tc.expectedError, err)
+				}
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+
+	assert.EqualError(t, &LowBalanceError{EffectiveBalance: util.NewAmount(50), Minimum: util.NewAmount(100)}, "user deposit balance 50 is below the minimum of 100")
+}
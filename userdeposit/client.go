@@ -0,0 +1,35 @@
+package userdeposit
+
+import (
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/config"
+)
+
+var (
+	_ Getter          = &Client{}
+	_ Depositor       = &Client{}
+	_ WithdrawPlanner = &Client{}
+	_ Withdrawer      = &Client{}
+)
+
+// NewClient creates a new client to all UserDeposit operations that can be
+// performed on a Raiden node. This includes Getting the deposit, Depositing,
+// Planning a withdrawal and Withdrawing.
+func NewClient(config *config.Config, httpClient *http.Client) *Client {
+	return &Client{
+		Getter:          NewGetter(config, httpClient),
+		Depositor:       NewDepositor(config, httpClient),
+		WithdrawPlanner: NewWithdrawPlanner(config, httpClient),
+		Withdrawer:      NewWithdrawer(config, httpClient),
+	}
+}
+
+// Client allows for all UserDeposit operations to be performed over HTTP calls to
+// a Raiden node.
+type Client struct {
+	Getter
+	Depositor
+	WithdrawPlanner
+	Withdrawer
+}
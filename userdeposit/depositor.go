@@ -0,0 +1,58 @@
+package userdeposit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type depositRequest struct {
+	TotalDeposit *util.Amount `json:"total_deposit"`
+}
+
+// Depositor is a generic interface to deposit tokens into the UserDeposit
+// contract.
+type Depositor interface {
+	Deposit(ctx context.Context, totalDeposit *util.Amount) (*Transaction, error)
+}
+
+var _ Depositor = &defaultDepositor{}
+
+// NewDepositor will create a default depositor for the user deposit of a
+// configured Raiden node.
+func NewDepositor(config *config.Config, httpClient *http.Client) Depositor {
+	return &defaultDepositor{
+		baseClient: &util.BaseClient{
+			Config:     config,
+			HTTPClient: httpClient,
+		},
+	}
+}
+
+type defaultDepositor struct {
+	baseClient *util.BaseClient
+}
+
+// Deposit will raise the total deposit of the node in the UserDeposit contract to
+// the given amount. Like channel deposits the amount is the new total and not the
+// amount to add, so it must exceed the current total deposit.
+func (depositor *defaultDepositor) Deposit(ctx context.Context, totalDeposit *util.Amount) (*Transaction, error) {
+	var (
+		err         error
+		transaction = &transaction{}
+	)
+
+	if totalDeposit.Sign() <= 0 {
+		return nil, errors.New("total deposit must be greater than zero")
+	}
+
+	if err = depositor.baseClient.Do(ctx, "POST", []string{"user_deposit"}, &depositRequest{TotalDeposit: totalDeposit}, transaction); err != nil {
+		return nil, err
+	}
+
+	return &Transaction{TransactionHash: common.HexToHash(transaction.TransactionHash)}, nil
+}
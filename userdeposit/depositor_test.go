@@ -0,0 +1,146 @@
+package userdeposit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleDepositor() {
+	var (
+		userDepositClient *Client
+		config            = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		totalDeposit = util.NewAmount(5000000000000000000)
+		transaction  *Transaction
+		err          error
+	)
+
+	userDepositClient = NewClient(config, http.DefaultClient)
+
+	if transaction, err = userDepositClient.Deposit(context.Background(), totalDeposit); err != nil {
+		panic(fmt.Sprintf("unable to deposit into user deposit: %s", err.Error()))
+	}
+
+	fmt.Printf("deposit transaction: %s\n", transaction.TransactionHash.Hex())
+}
+
+func TestDepositor(t *testing.T) {
+	var (
+		localhostIP = "[::1]"
+		config      = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+	)
+
+	if os.Getenv("USE_IPV4") != "" {
+		localhostIP = "127.0.0.1"
+	}
+
+	type testcase struct {
+		name                string
+		prepHTTPMock        func(requests *[]*depositRequest)
+		totalDeposit        *util.Amount
+		expectedTransaction *Transaction
+		expectedRequests    []*depositRequest
+		expectedError       error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "successfully deposited",
+			prepHTTPMock: func(requests *[]*depositRequest) {
+				httpmock.RegisterResponder("POST", "http://localhost:5001/api/v1/user_deposit", func(request *http.Request) (*http.Response, error) {
+					depositRequest := &depositRequest{}
+
+					if err := json.NewDecoder(request.Body).Decode(depositRequest); err != nil {
+						return nil, err
+					}
+
+					*requests = append(*requests, depositRequest)
+
+					return httpmock.NewStringResponse(
+						http.StatusOK,
+						`{"transaction_hash":"0xc5988dd8d3b8b2b2d1c9b0d1b1b1e5a3b7c9c1e0f5a3d3f1b1c9a0b2c4d6e8f0"}`,
+					), nil
+				})
+			},
+			totalDeposit:        util.NewAmount(200000),
+			expectedTransaction: &Transaction{TransactionHash: common.HexToHash("0xc5988dd8d3b8b2b2d1c9b0d1b1b1e5a3b7c9c1e0f5a3d3f1b1c9a0b2c4d6e8f0")},
+			expectedRequests:    []*depositRequest{&depositRequest{TotalDeposit: util.NewAmount(200000)}},
+		},
+		testcase{
+			name: "total deposit not increased",
+			prepHTTPMock: func(requests *[]*depositRequest) {
+				httpmock.RegisterResponder(
+					"POST",
+					"http://localhost:5001/api/v1/user_deposit",
+					httpmock.NewStringResponder(
+						http.StatusConflict,
+						`{"errors":"Attempted to lower the total deposit"}`,
+					),
+				)
+			},
+			totalDeposit:  util.NewAmount(100),
+			expectedError: &util.APIError{StatusCode: http.StatusConflict, Method: "POST", URL: "http://localhost:5001/api/v1/user_deposit", Errors: []string{"Attempted to lower the total deposit"}},
+		},
+		testcase{
+			name: "zero total deposit",
+			prepHTTPMock: func(requests *[]*depositRequest) {
+			},
+			totalDeposit:  util.NewAmount(0),
+			expectedError: errors.New("total deposit must be greater than zero"),
+		},
+		testcase{
+			name: "unable to make http request",
+			prepHTTPMock: func(requests *[]*depositRequest) {
+				httpmock.Deactivate()
+			},
+			totalDeposit:  util.NewAmount(200000),
+			expectedError: fmt.Errorf("Post http://localhost:5001/api/v1/user_deposit: dial tcp %s:5001: connect: connection refused", localhostIP),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				err         error
+				transaction *Transaction
+				requests    = make([]*depositRequest, 0)
+
+				depositor = NewDepositor(config, http.DefaultClient)
+				ctx       = context.Background()
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			tc.prepHTTPMock(&requests)
+
+			transaction, err = depositor.Deposit(ctx, tc.totalDeposit)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTransaction, transaction)
+			assert.Equal(t, tc.expectedRequests, requests)
+		})
+	}
+}
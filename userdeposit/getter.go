@@ -0,0 +1,47 @@
+package userdeposit
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+)
+
+// Getter is a generic interface to get the state of the node's deposit in the
+// UserDeposit contract.
+type Getter interface {
+	Get(ctx context.Context) (*UserDeposit, error)
+}
+
+var _ Getter = &defaultGetter{}
+
+// NewGetter will create a default getter for the user deposit of a configured
+// Raiden node.
+func NewGetter(config *config.Config, httpClient *http.Client) Getter {
+	return &defaultGetter{
+		baseClient: &util.BaseClient{
+			Config:     config,
+			HTTPClient: httpClient,
+		},
+	}
+}
+
+type defaultGetter struct {
+	baseClient *util.BaseClient
+}
+
+// Get will return the balance, total deposit and planned withdrawal of the node in
+// the UserDeposit contract.
+func (getter *defaultGetter) Get(ctx context.Context) (*UserDeposit, error) {
+	var (
+		err         error
+		userDeposit = &userDeposit{}
+	)
+
+	if err = getter.baseClient.Do(ctx, "GET", []string{"user_deposit"}, nil, userDeposit); err != nil {
+		return nil, err
+	}
+
+	return userDeposit.toUserDeposit(), nil
+}
@@ -0,0 +1,150 @@
+package userdeposit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleGetter() {
+	var (
+		userDepositClient *Client
+		config            = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		userDeposit *UserDeposit
+		err         error
+	)
+
+	userDepositClient = NewClient(config, http.DefaultClient)
+
+	if userDeposit, err = userDepositClient.Get(context.Background()); err != nil {
+		panic(fmt.Sprintf("unable to get user deposit: %s", err.Error()))
+	}
+
+	fmt.Printf("effective balance: %s\n", userDeposit.EffectiveBalance)
+}
+
+func TestGetter(t *testing.T) {
+	var (
+		localhostIP = "[::1]"
+		config      = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+	)
+
+	if os.Getenv("USE_IPV4") != "" {
+		localhostIP = "127.0.0.1"
+	}
+
+	type testcase struct {
+		name                string
+		prepHTTPMock        func()
+		expectedUserDeposit *UserDeposit
+		expectedError       error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "successfully got user deposit",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/user_deposit",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						`{"given_address":"0x2a65Aca4D5fC5B5C859090a6c34d164135398226","balance":"5000000000000000000","total_deposit":"5000000000000000000","effective_balance":"4000000000000000000","withdraw_plan":{"withdraw_amount":"1000000000000000000","withdraw_block":4269933}}`,
+					),
+				)
+			},
+			expectedUserDeposit: &UserDeposit{
+				Address:          common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226"),
+				Balance:          util.NewAmount(5000000000000000000),
+				TotalDeposit:     util.NewAmount(5000000000000000000),
+				EffectiveBalance: util.NewAmount(4000000000000000000),
+				WithdrawPlan: &WithdrawPlan{
+					Amount:        util.NewAmount(1000000000000000000),
+					WithdrawBlock: 4269933,
+				},
+			},
+		},
+		testcase{
+			name: "no withdraw planned",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/user_deposit",
+					httpmock.NewStringResponder(
+						http.StatusOK,
+						`{"given_address":"0x2a65Aca4D5fC5B5C859090a6c34d164135398226","balance":100,"total_deposit":100,"effective_balance":100,"withdraw_plan":{"withdraw_amount":0,"withdraw_block":0}}`,
+					),
+				)
+			},
+			expectedUserDeposit: &UserDeposit{
+				Address:          common.HexToAddress("0x2a65Aca4D5fC5B5C859090a6c34d164135398226"),
+				Balance:          util.NewAmount(100),
+				TotalDeposit:     util.NewAmount(100),
+				EffectiveBalance: util.NewAmount(100),
+			},
+		},
+		testcase{
+			name: "unexpected 500 response",
+			prepHTTPMock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/user_deposit",
+					httpmock.NewStringResponder(
+						http.StatusInternalServerError,
+						``,
+					),
+				)
+			},
+			expectedError: &util.APIError{StatusCode: http.StatusInternalServerError, Method: "GET", URL: "http://localhost:5001/api/v1/user_deposit"},
+		},
+		testcase{
+			name: "unable to make http request",
+			prepHTTPMock: func() {
+				httpmock.Deactivate()
+			},
+			expectedError: fmt.Errorf("Get http://localhost:5001/api/v1/user_deposit: dial tcp %s:5001: connect: connection refused", localhostIP),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				err         error
+				userDeposit *UserDeposit
+
+				getter = NewGetter(config, http.DefaultClient)
+				ctx    = context.Background()
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			tc.prepHTTPMock()
+
+			userDeposit, err = getter.Get(ctx)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedUserDeposit, userDeposit)
+		})
+	}
+}
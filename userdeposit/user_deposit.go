@@ -0,0 +1,76 @@
+// Package userdeposit manages the balance a Raiden node holds in the UserDeposit
+// contract, from which monitoring and pathfinding services are paid.
+package userdeposit
+
+import (
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type withdrawPlan struct {
+	WithdrawAmount *util.Amount `json:"withdraw_amount"`
+	WithdrawBlock  int64        `json:"withdraw_block"`
+}
+
+type userDeposit struct {
+	GivenAddress     string        `json:"given_address"`
+	Balance          *util.Amount  `json:"balance"`
+	TotalDeposit     *util.Amount  `json:"total_deposit"`
+	EffectiveBalance *util.Amount  `json:"effective_balance"`
+	WithdrawPlan     *withdrawPlan `json:"withdraw_plan"`
+}
+
+type transaction struct {
+	TransactionHash            string `json:"transaction_hash"`
+	PlannedWithdrawBlockNumber int64  `json:"planned_withdraw_block_number"`
+}
+
+// WithdrawPlan is a withdrawal from the UserDeposit contract that was announced
+// and can be carried out once the chain reaches WithdrawBlock.
+type WithdrawPlan struct {
+	Amount        *util.Amount
+	WithdrawBlock int64
+}
+
+// UserDeposit is the state of the node's deposit in the UserDeposit contract. The
+// EffectiveBalance is the Balance less any planned withdrawal and is what the
+// services can still be paid from.
+type UserDeposit struct {
+	Address          common.Address
+	Balance          *util.Amount
+	TotalDeposit     *util.Amount
+	EffectiveBalance *util.Amount
+	// WithdrawPlan is nil if no withdrawal is planned.
+	WithdrawPlan *WithdrawPlan
+}
+
+// Transaction is the on-chain transaction sent by the node for a UserDeposit
+// operation.
+type Transaction struct {
+	TransactionHash common.Hash
+}
+
+// PlannedWithdraw is the transaction announcing a withdrawal along with the block
+// from which the amount can be withdrawn.
+type PlannedWithdraw struct {
+	TransactionHash common.Hash
+	WithdrawBlock   int64
+}
+
+func (deposit *userDeposit) toUserDeposit() *UserDeposit {
+	userDeposit := &UserDeposit{
+		Address:          common.HexToAddress(deposit.GivenAddress),
+		Balance:          deposit.Balance,
+		TotalDeposit:     deposit.TotalDeposit,
+		EffectiveBalance: deposit.EffectiveBalance,
+	}
+
+	if deposit.WithdrawPlan != nil && deposit.WithdrawPlan.WithdrawAmount.Sign() > 0 {
+		userDeposit.WithdrawPlan = &WithdrawPlan{
+			Amount:        deposit.WithdrawPlan.WithdrawAmount,
+			WithdrawBlock: deposit.WithdrawPlan.WithdrawBlock,
+		}
+	}
+
+	return userDeposit
+}
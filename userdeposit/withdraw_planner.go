@@ -0,0 +1,61 @@
+package userdeposit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type planWithdrawRequest struct {
+	PlannedWithdrawAmount *util.Amount `json:"planned_withdraw_amount"`
+}
+
+// WithdrawPlanner is a generic interface to announce a withdrawal from the
+// UserDeposit contract.
+type WithdrawPlanner interface {
+	PlanWithdraw(ctx context.Context, amount *util.Amount) (*PlannedWithdraw, error)
+}
+
+var _ WithdrawPlanner = &defaultWithdrawPlanner{}
+
+// NewWithdrawPlanner will create a default withdraw planner for the user deposit
+// of a configured Raiden node.
+func NewWithdrawPlanner(config *config.Config, httpClient *http.Client) WithdrawPlanner {
+	return &defaultWithdrawPlanner{
+		baseClient: &util.BaseClient{
+			Config:     config,
+			HTTPClient: httpClient,
+		},
+	}
+}
+
+type defaultWithdrawPlanner struct {
+	baseClient *util.BaseClient
+}
+
+// PlanWithdraw will announce the withdrawal of the amount from the UserDeposit
+// contract. The amount stops counting towards the effective balance right away
+// and can be withdrawn from the returned block on.
+func (planner *defaultWithdrawPlanner) PlanWithdraw(ctx context.Context, amount *util.Amount) (*PlannedWithdraw, error) {
+	var (
+		err         error
+		transaction = &transaction{}
+	)
+
+	if amount.Sign() <= 0 {
+		return nil, errors.New("withdraw amount must be greater than zero")
+	}
+
+	if err = planner.baseClient.Do(ctx, "POST", []string{"user_deposit"}, &planWithdrawRequest{PlannedWithdrawAmount: amount}, transaction); err != nil {
+		return nil, err
+	}
+
+	return &PlannedWithdraw{
+		TransactionHash: common.HexToHash(transaction.TransactionHash),
+		WithdrawBlock:   transaction.PlannedWithdrawBlockNumber,
+	}, nil
+}
@@ -0,0 +1,146 @@
+package userdeposit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleWithdrawPlanner() {
+	var (
+		userDepositClient *Client
+		config            = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+		amount          = util.NewAmount(1000000000000000000)
+		plannedWithdraw *PlannedWithdraw
+		err             error
+	)
+
+	userDepositClient = NewClient(config, http.DefaultClient)
+
+	if plannedWithdraw, err = userDepositClient.PlanWithdraw(context.Background(), amount); err != nil {
+		panic(fmt.Sprintf("unable to plan withdraw from user deposit: %s", err.Error()))
+	}
+
+	fmt.Printf("withdrawable from block %d\n", plannedWithdraw.WithdrawBlock)
+}
+
+func TestWithdrawPlanner(t *testing.T) {
+	var (
+		localhostIP = "[::1]"
+		config      = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+	)
+
+	if os.Getenv("USE_IPV4") != "" {
+		localhostIP = "127.0.0.1"
+	}
+
+	type testcase struct {
+		name             string
+		prepHTTPMock     func(requests *[]*planWithdrawRequest)
+		amount           *util.Amount
+		expectedWithdraw *PlannedWithdraw
+		expectedRequests []*planWithdrawRequest
+		expectedError    error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "successfully planned withdraw",
+			prepHTTPMock: func(requests *[]*planWithdrawRequest) {
+				httpmock.RegisterResponder("POST", "http://localhost:5001/api/v1/user_deposit", func(request *http.Request) (*http.Response, error) {
+					planWithdrawRequest := &planWithdrawRequest{}
+
+					if err := json.NewDecoder(request.Body).Decode(planWithdrawRequest); err != nil {
+						return nil, err
+					}
+
+					*requests = append(*requests, planWithdrawRequest)
+
+					return httpmock.NewStringResponse(
+						http.StatusOK,
+						`{"planned_withdraw_block_number":4269933,"transaction_hash":"0xc5988dd8d3b8b2b2d1c9b0d1b1b1e5a3b7c9c1e0f5a3d3f1b1c9a0b2c4d6e8f0"}`,
+					), nil
+				})
+			},
+			amount:           util.NewAmount(200000),
+			expectedWithdraw: &PlannedWithdraw{TransactionHash: common.HexToHash("0xc5988dd8d3b8b2b2d1c9b0d1b1b1e5a3b7c9c1e0f5a3d3f1b1c9a0b2c4d6e8f0"), WithdrawBlock: 4269933},
+			expectedRequests: []*planWithdrawRequest{&planWithdrawRequest{PlannedWithdrawAmount: util.NewAmount(200000)}},
+		},
+		testcase{
+			name: "withdraw exceeds balance",
+			prepHTTPMock: func(requests *[]*planWithdrawRequest) {
+				httpmock.RegisterResponder(
+					"POST",
+					"http://localhost:5001/api/v1/user_deposit",
+					httpmock.NewStringResponder(
+						http.StatusConflict,
+						`{"errors":"The planned withdraw amount exceeds the total deposit"}`,
+					),
+				)
+			},
+			amount:        util.NewAmount(100),
+			expectedError: &util.APIError{StatusCode: http.StatusConflict, Method: "POST", URL: "http://localhost:5001/api/v1/user_deposit", Errors: []string{"The planned withdraw amount exceeds the total deposit"}},
+		},
+		testcase{
+			name: "zero amount",
+			prepHTTPMock: func(requests *[]*planWithdrawRequest) {
+			},
+			amount:        util.NewAmount(0),
+			expectedError: errors.New("withdraw amount must be greater than zero"),
+		},
+		testcase{
+			name: "unable to make http request",
+			prepHTTPMock: func(requests *[]*planWithdrawRequest) {
+				httpmock.Deactivate()
+			},
+			amount:        util.NewAmount(200000),
+			expectedError: fmt.Errorf("Post http://localhost:5001/api/v1/user_deposit: dial tcp %s:5001: connect: connection refused", localhostIP),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				err             error
+				plannedWithdraw *PlannedWithdraw
+				requests        = make([]*planWithdrawRequest, 0)
+
+				planner = NewWithdrawPlanner(config, http.DefaultClient)
+				ctx     = context.Background()
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			tc.prepHTTPMock(&requests)
+
+			plannedWithdraw, err = planner.PlanWithdraw(ctx, tc.amount)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedWithdraw, plannedWithdraw)
+			assert.Equal(t, tc.expectedRequests, requests)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package userdeposit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/cpurta/go-raiden-client/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type withdrawRequest struct {
+	WithdrawAmount *util.Amount `json:"withdraw_amount"`
+}
+
+// Withdrawer is a generic interface to withdraw tokens from the UserDeposit
+// contract.
+type Withdrawer interface {
+	Withdraw(ctx context.Context, amount *util.Amount) (*Transaction, error)
+}
+
+var _ Withdrawer = &defaultWithdrawer{}
+
+// NewWithdrawer will create a default withdrawer for the user deposit of a
+// configured Raiden node.
+func NewWithdrawer(config *config.Config, httpClient *http.Client) Withdrawer {
+	return &defaultWithdrawer{
+		baseClient: &util.BaseClient{
+			Config:     config,
+			HTTPClient: httpClient,
+		},
+	}
+}
+
+type defaultWithdrawer struct {
+	baseClient *util.BaseClient
+}
+
+// Withdraw will withdraw the amount from the UserDeposit contract to the node's
+// account. The withdrawal must have been planned with at most the same amount and
+// its withdraw block must have been reached, otherwise the node rejects it.
+func (withdrawer *defaultWithdrawer) Withdraw(ctx context.Context, amount *util.Amount) (*Transaction, error) {
+	var (
+		err         error
+		transaction = &transaction{}
+	)
+
+	if amount.Sign() <= 0 {
+		return nil, errors.New("withdraw amount must be greater than zero")
+	}
+
+	if err = withdrawer.baseClient.Do(ctx, "POST", []string{"user_deposit"}, &withdrawRequest{WithdrawAmount: amount}, transaction); err != nil {
+		return nil, err
+	}
+
+	return &Transaction{TransactionHash: common.HexToHash(transaction.TransactionHash)}, nil
+}
@@ -0,0 +1,123 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// Amount is an arbitrary-precision token amount expressed in the token's base
+// units. ERC-20 tokens commonly use 18 decimals which overflows an int64 at
+// roughly 9.2 tokens, so all amounts sent to and received from a Raiden node
+// use Amount. A nil *Amount is treated as zero.
+type Amount struct {
+	value *big.Int
+}
+
+// NewAmount returns an Amount holding the given number of base units.
+func NewAmount(value int64) *Amount {
+	return NewAmountFromBig(big.NewInt(value))
+}
+
+// NewAmountFromBig returns an Amount holding a copy of the given big integer.
+func NewAmountFromBig(value *big.Int) *Amount {
+	amount := &Amount{
+		value: new(big.Int),
+	}
+
+	if value != nil && value.Sign() != 0 {
+		amount.value.Set(value)
+	}
+
+	return amount
+}
+
+// ParseAmount parses a base 10 integer string, e.g. "1000000000000000000", into
+// an Amount.
+func ParseAmount(value string) (*Amount, error) {
+	var (
+		parsed *big.Int
+		ok     bool
+	)
+
+	if parsed, ok = new(big.Int).SetString(value, 10); !ok {
+		return nil, fmt.Errorf("invalid amount: %q", value)
+	}
+
+	return NewAmountFromBig(parsed), nil
+}
+
+// Big returns a copy of the amount as a *big.Int.
+func (amount *Amount) Big() *big.Int {
+	if amount == nil || amount.value == nil {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Set(amount.value)
+}
+
+// String returns the amount as a base 10 integer string.
+func (amount *Amount) String() string {
+	return amount.Big().String()
+}
+
+// Sign returns -1, 0 or 1 depending on whether the amount is negative, zero or
+// positive.
+func (amount *Amount) Sign() int {
+	return amount.Big().Sign()
+}
+
+// IsZero returns true if the amount is zero.
+func (amount *Amount) IsZero() bool {
+	return amount.Sign() == 0
+}
+
+// Cmp compares the amount to other and returns -1, 0 or 1 if the amount is less
+// than, equal to or greater than other.
+func (amount *Amount) Cmp(other *Amount) int {
+	return amount.Big().Cmp(other.Big())
+}
+
+// Add returns a new Amount holding the sum of the amount and other.
+func (amount *Amount) Add(other *Amount) *Amount {
+	return NewAmountFromBig(new(big.Int).Add(amount.Big(), other.Big()))
+}
+
+// Sub returns a new Amount holding the difference of the amount and other.
+func (amount *Amount) Sub(other *Amount) *Amount {
+	return NewAmountFromBig(new(big.Int).Sub(amount.Big(), other.Big()))
+}
+
+// MarshalJSON encodes the amount as a JSON number without any loss of precision.
+func (amount *Amount) MarshalJSON() ([]byte, error) {
+	return []byte(amount.String()), nil
+}
+
+// UnmarshalJSON decodes the amount from either a JSON number or a JSON string
+// holding a base 10 integer.
+func (amount *Amount) UnmarshalJSON(data []byte) error {
+	var (
+		err    error
+		value  = string(bytes.TrimSpace(data))
+		parsed *Amount
+	)
+
+	if value == "null" {
+		return nil
+	}
+
+	if len(value) > 0 && value[0] == '"' {
+		if err = json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+	}
+
+	if parsed, err = ParseAmount(value); err != nil {
+		return err
+	}
+
+	amount.value = parsed.value
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package util
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAmountUnmarshalJSON(t *testing.T) {
+	var (
+		oneHundredDAI, _ = new(big.Int).SetString("100000000000000000000", 10)
+	)
+
+	type testcase struct {
+		name           string
+		json           string
+		expectedAmount *Amount
+		expectedError  bool
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:           "json number",
+			json:           `{"amount":25000000}`,
+			expectedAmount: NewAmount(25000000),
+		},
+		testcase{
+			name:           "json number larger than int64",
+			json:           `{"amount":100000000000000000000}`,
+			expectedAmount: NewAmountFromBig(oneHundredDAI),
+		},
+		testcase{
+			name:           "json string",
+			json:           `{"amount":"100000000000000000000"}`,
+			expectedAmount: NewAmountFromBig(oneHundredDAI),
+		},
+		testcase{
+			name:           "zero",
+			json:           `{"amount":0}`,
+			expectedAmount: NewAmount(0),
+		},
+		testcase{
+			name:           "null",
+			json:           `{"amount":null}`,
+			expectedAmount: nil,
+		},
+		testcase{
+			name:          "invalid string",
+			json:          `{"amount":"12.5"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				err     error
+				decoded = &struct {
+					Amount *Amount `json:"amount"`
+				}{}
+			)
+
+			err = json.Unmarshal([]byte(tc.json), decoded)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAmount, decoded.Amount)
+		})
+	}
+}
+
+func TestAmountMarshalJSON(t *testing.T) {
+	var (
+		amount, _ = ParseAmount("100000000000000000000")
+		encoded   []byte
+		err       error
+	)
+
+	encoded, err = json.Marshal(&struct {
+		Amount *Amount `json:"amount"`
+	}{
+		Amount: amount,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"amount":100000000000000000000}`, string(encoded))
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	var (
+		five      = NewAmount(5)
+		three     = NewAmount(3)
+		nilAmount *Amount
+	)
+
+	assert.Equal(t, "8", five.Add(three).String())
+	assert.Equal(t, "2", five.Sub(three).String())
+	assert.Equal(t, 1, five.Cmp(three))
+	assert.Equal(t, -1, three.Cmp(five))
+	assert.Equal(t, 0, five.Cmp(NewAmount(5)))
+	assert.True(t, nilAmount.IsZero())
+	assert.Equal(t, "0", nilAmount.String())
+	assert.Equal(t, "5", five.Add(nilAmount).String())
+}
@@ -0,0 +1,170 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned by every sub-client when a Raiden node responds with a
+// non-2xx status code. It carries the HTTP status, the request method and URL,
+// and any error messages the node returned in its errors payload.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	Errors     []string
+}
+
+type errorResponse struct {
+	Errors json.RawMessage `json:"errors"`
+}
+
+// Error returns a human readable description of the failed API call.
+func (apiError *APIError) Error() string {
+	message := fmt.Sprintf("%s %s: %d %s", apiError.Method, apiError.URL, apiError.StatusCode, http.StatusText(apiError.StatusCode))
+
+	if len(apiError.Errors) > 0 {
+		message = fmt.Sprintf("%s: %s", message, strings.Join(apiError.Errors, "; "))
+	}
+
+	return message
+}
+
+// CheckResponse will return nil if the response has a 2xx status code, otherwise
+// it will consume the response body and return an *APIError describing the failure.
+func CheckResponse(response *http.Response) error {
+	var (
+		err          error
+		responseBody []byte
+		apiError     = &APIError{
+			StatusCode: response.StatusCode,
+		}
+	)
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+
+	if response.Request != nil {
+		apiError.Method = response.Request.Method
+
+		if response.Request.URL != nil {
+			apiError.URL = response.Request.URL.String()
+		}
+	}
+
+	if responseBody, err = ioutil.ReadAll(response.Body); err != nil {
+		return apiError
+	}
+
+	apiError.Errors = parseErrors(responseBody)
+
+	return apiError
+}
+
+// parseErrors extracts the error messages from a Raiden error payload. The node
+// returns either a single string or a list of strings under the errors key. If
+// the body is not a Raiden error payload the raw body is used instead.
+func parseErrors(body []byte) []string {
+	var (
+		message  string
+		messages []string
+		response = &errorResponse{}
+	)
+
+	body = []byte(strings.TrimSpace(string(body)))
+
+	if len(body) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, response); err != nil || len(response.Errors) == 0 {
+		return []string{string(body)}
+	}
+
+	if err := json.Unmarshal(response.Errors, &message); err == nil {
+		return []string{message}
+	}
+
+	if err := json.Unmarshal(response.Errors, &messages); err == nil {
+		return messages
+	}
+
+	return []string{string(response.Errors)}
+}
+
+// WrappedError adds context to an error of a call to a Raiden node, e.g. which
+// step of an operation made of several calls failed, while keeping the original
+// error, such as an *APIError, in Err.
+type WrappedError struct {
+	Message string
+	Err     error
+}
+
+// Error returns the context followed by the original error.
+func (wrappedError *WrappedError) Error() string {
+	return fmt.Sprintf("%s: %s", wrappedError.Message, wrappedError.Err.Error())
+}
+
+// Unwrap returns the original error.
+func (wrappedError *WrappedError) Unwrap() error {
+	return wrappedError.Err
+}
+
+// WrapError returns a *WrappedError holding err with a message formatted from
+// format and args.
+func WrapError(err error, format string, args ...interface{}) error {
+	return &WrappedError{
+		Message: fmt.Sprintf(format, args...),
+		Err:     err,
+	}
+}
+
+// Cause returns the original error held by err, unwrapping any *WrappedError.
+func Cause(err error) error {
+	for {
+		wrappedError, ok := err.(*WrappedError)
+
+		if !ok {
+			return err
+		}
+
+		err = wrappedError.Err
+	}
+}
+
+// AsAPIError returns the *APIError held by err and true if err is an API error
+// returned from a Raiden node, even if it was wrapped in a *WrappedError.
+func AsAPIError(err error) (*APIError, bool) {
+	apiError, ok := Cause(err).(*APIError)
+
+	return apiError, ok
+}
+
+// IsConflict returns true if the Raiden node rejected the request with a 409
+// Conflict, e.g. when the channel state does not allow the operation or there
+// are insufficient funds to deposit.
+func IsConflict(err error) bool {
+	return hasStatusCode(err, http.StatusConflict)
+}
+
+// IsPaymentRequired returns true if the Raiden node rejected the request with a
+// 402 Payment Required, which it uses to signal insufficient balance.
+func IsPaymentRequired(err error) bool {
+	return hasStatusCode(err, http.StatusPaymentRequired)
+}
+
+// IsNotFound returns true if the Raiden node responded with a 404 Not Found,
+// e.g. when the requested channel or token network is unknown.
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	apiError, ok := AsAPIError(err)
+
+	return ok && apiError.StatusCode == statusCode
+}
@@ -0,0 +1,131 @@
+package util
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckResponse(t *testing.T) {
+	type testcase struct {
+		name          string
+		statusCode    int
+		body          string
+		expectedError error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:          "successful response",
+			statusCode:    http.StatusOK,
+			body:          `{}`,
+			expectedError: nil,
+		},
+		testcase{
+			name:          "successful no content response",
+			statusCode:    http.StatusNoContent,
+			body:          ``,
+			expectedError: nil,
+		},
+		testcase{
+			name:       "single error message",
+			statusCode: http.StatusConflict,
+			body:       `{"errors":"Channel is already closed"}`,
+			expectedError: &APIError{
+				StatusCode: http.StatusConflict,
+				Method:     "PATCH",
+				URL:        "http://localhost:5001/api/v1/channels",
+				Errors:     []string{"Channel is already closed"},
+			},
+		},
+		testcase{
+			name:       "multiple error messages",
+			statusCode: http.StatusBadRequest,
+			body:       `{"errors":["Invalid partner address","Invalid token address"]}`,
+			expectedError: &APIError{
+				StatusCode: http.StatusBadRequest,
+				Method:     "PATCH",
+				URL:        "http://localhost:5001/api/v1/channels",
+				Errors:     []string{"Invalid partner address", "Invalid token address"},
+			},
+		},
+		testcase{
+			name:       "non json error body",
+			statusCode: http.StatusBadGateway,
+			body:       "upstream unavailable\n",
+			expectedError: &APIError{
+				StatusCode: http.StatusBadGateway,
+				Method:     "PATCH",
+				URL:        "http://localhost:5001/api/v1/channels",
+				Errors:     []string{"upstream unavailable"},
+			},
+		},
+		testcase{
+			name:       "empty error body",
+			statusCode: http.StatusInternalServerError,
+			body:       ``,
+			expectedError: &APIError{
+				StatusCode: http.StatusInternalServerError,
+				Method:     "PATCH",
+				URL:        "http://localhost:5001/api/v1/channels",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			requestURL, _ := url.Parse("http://localhost:5001/api/v1/channels")
+
+			response := &http.Response{
+				StatusCode: tc.statusCode,
+				Body:       ioutil.NopCloser(strings.NewReader(tc.body)),
+				Request: &http.Request{
+					Method: "PATCH",
+					URL:    requestURL,
+				},
+			}
+
+			assert.Equal(t, tc.expectedError, CheckResponse(response))
+		})
+	}
+}
+
+func TestAPIErrorHelpers(t *testing.T) {
+	var (
+		conflict        = &APIError{StatusCode: http.StatusConflict}
+		paymentRequired = &APIError{StatusCode: http.StatusPaymentRequired}
+		notFound        = &APIError{StatusCode: http.StatusNotFound}
+		other           = errors.New("connection refused")
+	)
+
+	assert.True(t, IsConflict(conflict))
+	assert.False(t, IsConflict(notFound))
+	assert.False(t, IsConflict(other))
+
+	assert.True(t, IsPaymentRequired(paymentRequired))
+	assert.False(t, IsPaymentRequired(conflict))
+
+	assert.True(t, IsNotFound(notFound))
+	assert.False(t, IsNotFound(paymentRequired))
+	assert.False(t, IsNotFound(nil))
+
+	// wrapped errors keep their status code and their original error
+	wrapped := WrapError(WrapError(conflict, "unable to reconcile payment %d", 42), "unable to pay")
+
+	assert.True(t, IsConflict(wrapped))
+	assert.False(t, IsNotFound(wrapped))
+	assert.Equal(t, conflict, Cause(wrapped))
+	assert.Equal(t, other, Cause(other))
+	assert.EqualError(t, WrapError(other, "unable to reconcile payment %d", 42), "unable to reconcile payment 42: connection refused")
+
+	assert.EqualError(
+		t,
+		&APIError{StatusCode: http.StatusConflict, Method: "PATCH", URL: "http://localhost:5001/api/v1/channels", Errors: []string{"already closed"}},
+		"PATCH http://localhost:5001/api/v1/channels: 409 Conflict: already closed",
+	)
+}
@@ -1,15 +1,169 @@
 package util
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/cpurta/go-raiden-client/config"
 )
 
 // BaseClient serves as the HTTP client responsible for making all outbound requests
 // to the Raiden node as specified in the Config. It allows for HTTP requests to be
-// built onto and add headers if needed.
+// built onto and adds the credentials of the configured Authenticator.
 type BaseClient struct {
 	Config     *config.Config
 	HTTPClient *http.Client
 }
+
+// URL builds the URL of a Raiden API endpoint from the configured host and API
+// version and the given path segments, e.g. ("channels", tokenAddress.Hex()).
+func (client *BaseClient) URL(pathSegments ...string) (*url.URL, error) {
+	var (
+		err        error
+		endpoint   = fmt.Sprintf("%s/api/%s", client.Config.Host, client.Config.APIVersion)
+		requestURL *url.URL
+	)
+
+	for _, segment := range pathSegments {
+		endpoint = fmt.Sprintf("%s/%s", endpoint, url.PathEscape(segment))
+	}
+
+	if requestURL, err = url.Parse(endpoint); err != nil {
+		return nil, err
+	}
+
+	return requestURL, nil
+}
+
+// Do will make a request to the Raiden API endpoint made up of the given path
+// segments. If body is non-nil it is encoded as the JSON request body and if out
+// is non-nil the JSON response body is decoded into it. Any non-2xx response is
+// returned as an *APIError.
+func (client *BaseClient) Do(ctx context.Context, method string, pathSegments []string, body, out interface{}) error {
+	var (
+		err        error
+		requestURL *url.URL
+	)
+
+	if requestURL, err = client.URL(pathSegments...); err != nil {
+		return err
+	}
+
+	return client.DoURL(ctx, method, requestURL, body, out)
+}
+
+// DoURL behaves like Do but makes the request to an already built URL. This is
+// useful for endpoints that require query parameters. If the config has a retry
+// policy, idempotent requests are retried on transient errors and the error of
+// the last attempt is returned.
+func (client *BaseClient) DoURL(ctx context.Context, method string, requestURL *url.URL, body, out interface{}) error {
+	var (
+		err         error
+		retryable   bool
+		encodedBody []byte
+		attempts    = 1
+		policy      = client.Config.RetryPolicy
+	)
+
+	method = strings.ToUpper(method)
+
+	if body != nil {
+		if encodedBody, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	if policy != nil && policy.MaxAttempts > 1 && IsIdempotent(ctx, method) {
+		attempts = policy.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		if retryable, err = client.do(ctx, method, requestURL, body != nil, encodedBody, out); err == nil {
+			return nil
+		}
+
+		if !retryable || attempt >= attempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(policy.Backoff(attempt)):
+		}
+	}
+}
+
+// do makes a single attempt of a request, limited by the config's timeout, and
+// returns whether a failed attempt may be retried according to the retry policy.
+func (client *BaseClient) do(parentCtx context.Context, method string, requestURL *url.URL, hasBody bool, encodedBody []byte, out interface{}) (bool, error) {
+	var (
+		err         error
+		request     *http.Request
+		response    *http.Response
+		requestBody io.Reader
+		ctx         = parentCtx
+	)
+
+	if client.Config.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, client.Config.Timeout)
+		defer cancel()
+	}
+
+	if hasBody {
+		requestBody = bytes.NewReader(encodedBody)
+	}
+
+	if request, err = http.NewRequest(method, requestURL.String(), requestBody); err != nil {
+		return false, err
+	}
+
+	request = request.WithContext(ctx)
+
+	request.Header.Set("Accept", "application/json")
+
+	if hasBody {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	if client.Config.Authenticator != nil {
+		if err = client.Config.Authenticator.Authenticate(request, encodedBody); err != nil {
+			return false, err
+		}
+	}
+
+	if response, err = client.HTTPClient.Do(request); err != nil {
+		return parentCtx.Err() == nil, err
+	}
+
+	defer response.Body.Close()
+
+	if err = CheckResponse(response); err != nil {
+		policy := client.Config.RetryPolicy
+
+		return policy != nil && policy.IsRetryableStatus(response.StatusCode), err
+	}
+
+	if out == nil {
+		return false, nil
+	}
+
+	if err = json.NewDecoder(response.Body).Decode(out); err != nil {
+		if err == io.EOF && response.StatusCode == http.StatusNoContent {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return false, nil
+}
@@ -0,0 +1,385 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cpurta/go-raiden-client/config"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRequest struct {
+	State string `json:"state"`
+}
+
+type testResponse struct {
+	Identifier int64 `json:"identifier"`
+}
+
+func TestBaseClientDo(t *testing.T) {
+	var (
+		config = &config.Config{
+			Host:       "http://localhost:5001",
+			APIVersion: "v1",
+		}
+	)
+
+	type testcase struct {
+		name             string
+		method           string
+		pathSegments     []string
+		body             interface{}
+		out              *testResponse
+		prepHTTPMock     func(t *testing.T)
+		expectedResponse *testResponse
+		expectedError    error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:         "successfully encodes request and decodes response",
+			method:       "PATCH",
+			pathSegments: []string{"channels", "0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"},
+			body:         &testRequest{State: "closed"},
+			out:          &testResponse{},
+			prepHTTPMock: func(t *testing.T) {
+				httpmock.RegisterResponder(
+					"PATCH",
+					"http://localhost:5001/api/v1/channels/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8",
+					func(request *http.Request) (*http.Response, error) {
+						var body = &testRequest{}
+
+						assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+						assert.Equal(t, "application/json", request.Header.Get("Accept"))
+						require.NoError(t, json.NewDecoder(request.Body).Decode(body))
+						assert.Equal(t, "closed", body.State)
+
+						return httpmock.NewStringResponse(http.StatusOK, `{"identifier":42}`), nil
+					},
+				)
+			},
+			expectedResponse: &testResponse{Identifier: 42},
+			expectedError:    nil,
+		},
+		testcase{
+			name:         "no content response is not decoded",
+			method:       "PUT",
+			pathSegments: []string{"connections", "0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8"},
+			body:         nil,
+			out:          &testResponse{},
+			prepHTTPMock: func(t *testing.T) {
+				httpmock.RegisterResponder(
+					"PUT",
+					"http://localhost:5001/api/v1/connections/0xEA674fdDe714fd979de3EdF0F56AA9716B898ec8",
+					httpmock.NewStringResponder(http.StatusNoContent, ``),
+				)
+			},
+			expectedResponse: &testResponse{},
+			expectedError:    nil,
+		},
+		testcase{
+			name:         "non 2xx response returns api error",
+			method:       "GET",
+			pathSegments: []string{"channels"},
+			body:         nil,
+			out:          &testResponse{},
+			prepHTTPMock: func(t *testing.T) {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/channels",
+					httpmock.NewStringResponder(http.StatusNotFound, `{"errors":"Channel not found"}`),
+				)
+			},
+			expectedResponse: &testResponse{},
+			expectedError: &APIError{
+				StatusCode: http.StatusNotFound,
+				Method:     "GET",
+				URL:        "http://localhost:5001/api/v1/channels",
+				Errors:     []string{"Channel not found"},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				err        error
+				baseClient = &BaseClient{
+					Config:     config,
+					HTTPClient: http.DefaultClient,
+				}
+				ctx = context.Background()
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			tc.prepHTTPMock(t)
+
+			err = baseClient.Do(ctx, tc.method, tc.pathSegments, tc.body, tc.out)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResponse, tc.out)
+		})
+	}
+}
+
+func TestBaseClientAuthenticator(t *testing.T) {
+	type testcase struct {
+		name          string
+		authenticator config.Authenticator
+		prepHTTPMock  func(t *testing.T)
+		expectedError error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:          "authenticator is applied to the request",
+			authenticator: config.BearerToken("secret-token"),
+			prepHTTPMock: func(t *testing.T) {
+				httpmock.RegisterResponder(
+					"GET",
+					"http://localhost:5001/api/v1/address",
+					func(request *http.Request) (*http.Response, error) {
+						if request.Header.Get("Authorization") != "Bearer secret-token" {
+							return httpmock.NewStringResponse(http.StatusUnauthorized, `{"errors":"missing token"}`), nil
+						}
+
+						return httpmock.NewStringResponse(http.StatusOK, `{"identifier":1}`), nil
+					},
+				)
+			},
+			expectedError: nil,
+		},
+		testcase{
+			name: "authenticator error aborts the request",
+			authenticator: config.AuthenticatorFunc(func(request *http.Request, body []byte) error {
+				return errors.New("token expired")
+			}),
+			prepHTTPMock:  func(t *testing.T) {},
+			expectedError: errors.New("token expired"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				err        error
+				baseClient = &BaseClient{
+					Config: &config.Config{
+						Host:          "http://localhost:5001",
+						APIVersion:    "v1",
+						Authenticator: tc.authenticator,
+					},
+					HTTPClient: http.DefaultClient,
+				}
+				ctx = context.Background()
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			tc.prepHTTPMock(t)
+
+			err = baseClient.Do(ctx, "GET", []string{"address"}, nil, &testResponse{})
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestBaseClientRetry(t *testing.T) {
+	var (
+		retryPolicy = &config.RetryPolicy{
+			MaxAttempts:          3,
+			InitialBackoff:       time.Millisecond,
+			MaxBackoff:           time.Millisecond,
+			RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+		}
+	)
+
+	type testcase struct {
+		name          string
+		method        string
+		ctx           context.Context
+		retryPolicy   *config.RetryPolicy
+		statusCodes   []int
+		expectedCalls int
+		expectedError error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:          "get is retried until it succeeds",
+			method:        "GET",
+			ctx:           context.Background(),
+			retryPolicy:   retryPolicy,
+			statusCodes:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedCalls: 2,
+			expectedError: nil,
+		},
+		testcase{
+			name:          "get returns the last error once attempts are exhausted",
+			method:        "GET",
+			ctx:           context.Background(),
+			retryPolicy:   retryPolicy,
+			statusCodes:   []int{http.StatusServiceUnavailable},
+			expectedCalls: 3,
+			expectedError: &APIError{StatusCode: http.StatusServiceUnavailable, Method: "GET", URL: "http://localhost:5001/api/v1/payments"},
+		},
+		testcase{
+			name:          "get is not retried on a non retryable status",
+			method:        "GET",
+			ctx:           context.Background(),
+			retryPolicy:   retryPolicy,
+			statusCodes:   []int{http.StatusConflict},
+			expectedCalls: 1,
+			expectedError: &APIError{StatusCode: http.StatusConflict, Method: "GET", URL: "http://localhost:5001/api/v1/payments"},
+		},
+		testcase{
+			name:          "get is not retried without a policy",
+			method:        "GET",
+			ctx:           context.Background(),
+			retryPolicy:   nil,
+			statusCodes:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedCalls: 1,
+			expectedError: &APIError{StatusCode: http.StatusServiceUnavailable, Method: "GET", URL: "http://localhost:5001/api/v1/payments"},
+		},
+		testcase{
+			name:          "post is not retried",
+			method:        "POST",
+			ctx:           context.Background(),
+			retryPolicy:   retryPolicy,
+			statusCodes:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedCalls: 1,
+			expectedError: &APIError{StatusCode: http.StatusServiceUnavailable, Method: "POST", URL: "http://localhost:5001/api/v1/payments"},
+		},
+		testcase{
+			name:          "idempotent post is retried with the same body",
+			method:        "POST",
+			ctx:           WithIdempotent(context.Background()),
+			retryPolicy:   retryPolicy,
+			statusCodes:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedCalls: 2,
+			expectedError: nil,
+		},
+		testcase{
+			name:          "post marked as not idempotent is not retried",
+			method:        "POST",
+			ctx:           WithoutIdempotent(WithIdempotent(context.Background())),
+			retryPolicy:   retryPolicy,
+			statusCodes:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedCalls: 1,
+			expectedError: &APIError{StatusCode: http.StatusServiceUnavailable, Method: "POST", URL: "http://localhost:5001/api/v1/payments"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				err        error
+				calls      = 0
+				baseClient = &BaseClient{
+					Config: &config.Config{
+						Host:        "http://localhost:5001",
+						APIVersion:  "v1",
+						RetryPolicy: tc.retryPolicy,
+					},
+					HTTPClient: http.DefaultClient,
+				}
+			)
+
+			httpmock.Activate()
+			defer httpmock.Deactivate()
+
+			httpmock.RegisterResponder(
+				tc.method,
+				"http://localhost:5001/api/v1/payments",
+				func(request *http.Request) (*http.Response, error) {
+					statusCode := tc.statusCodes[len(tc.statusCodes)-1]
+
+					if calls < len(tc.statusCodes) {
+						statusCode = tc.statusCodes[calls]
+					}
+
+					calls++
+
+					if request.Body != nil {
+						body, _ := ioutil.ReadAll(request.Body)
+						assert.Equal(t, `{"state":"closed"}`, string(body))
+					}
+
+					if statusCode != http.StatusOK {
+						response := httpmock.NewStringResponse(statusCode, ``)
+						response.Request = request
+
+						return response, nil
+					}
+
+					return httpmock.NewStringResponse(statusCode, `{"identifier":1}`), nil
+				},
+			)
+
+			var body interface{}
+
+			if tc.method == "POST" {
+				body = &testRequest{State: "closed"}
+			}
+
+			err = baseClient.Do(tc.ctx, tc.method, []string{"payments"}, body, &testResponse{})
+
+			assert.Equal(t, tc.expectedCalls, calls)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestBaseClientTimeout(t *testing.T) {
+	var (
+		server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			select {
+			case <-request.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}))
+		baseClient = &BaseClient{
+			Config: &config.Config{
+				Host:       server.URL,
+				APIVersion: "v1",
+				Timeout:    10 * time.Millisecond,
+			},
+			HTTPClient: http.DefaultClient,
+		}
+	)
+
+	defer server.Close()
+
+	err := baseClient.Do(context.Background(), "GET", []string{"address"}, nil, &testResponse{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "context deadline exceeded")
+}
@@ -0,0 +1,33 @@
+package util
+
+import "context"
+
+type idempotentKey struct{}
+
+// WithIdempotent returns a context marking requests made with it as safe to
+// retry even though their method is not idempotent, e.g. a pathfinding query
+// that only reads the routes of a payment.
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// WithoutIdempotent returns a context marking requests made with it as unsafe to
+// retry, even if a parent context was marked with WithIdempotent. Requests that
+// move funds, e.g. payments, use it so that a timed out attempt the node may
+// still have processed is never sent a second time.
+func WithoutIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, false)
+}
+
+// IsIdempotent returns whether a request with the given method and context is
+// safe to retry. GET and HEAD requests are always idempotent, any other request
+// only if its context has been marked with WithIdempotent.
+func IsIdempotent(ctx context.Context, method string) bool {
+	if method == "GET" || method == "HEAD" {
+		return true
+	}
+
+	idempotent, _ := ctx.Value(idempotentKey{}).(bool)
+
+	return idempotent
+}
@@ -0,0 +1,77 @@
+package util
+
+import (
+	"context"
+	"time"
+)
+
+// Poller repeatedly calls a poll function, waiting an interval between two
+// successful polls and backing off exponentially while polls fail.
+type Poller struct {
+	interval   time.Duration
+	maxBackoff time.Duration
+}
+
+// NewPoller will create a poller that polls every interval and doubles the time
+// between polls after every failed poll up to maxBackoff. A maxBackoff below the
+// interval is raised to the interval.
+func NewPoller(interval, maxBackoff time.Duration) *Poller {
+	if maxBackoff < interval {
+		maxBackoff = interval
+	}
+
+	return &Poller{
+		interval:   interval,
+		maxBackoff: maxBackoff,
+	}
+}
+
+// Poll will call poll right away and then again after every interval until the
+// context is done. If poll returns true the next poll is made right away, e.g.
+// because a full page was received. Errors returned by poll are passed to onError
+// unless the context is done, and delay the next poll by the backoff.
+func (poller *Poller) Poll(ctx context.Context, poll func() (bool, error), onError func(error)) {
+	var (
+		delay = time.Duration(0)
+		timer = time.NewTimer(delay)
+	)
+
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		more, err := poll()
+
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			onError(err)
+
+			delay = poller.backoff(delay)
+		case more:
+			delay = 0
+		default:
+			delay = poller.interval
+		}
+
+		timer.Reset(delay)
+	}
+}
+
+func (poller *Poller) backoff(delay time.Duration) time.Duration {
+	if delay < poller.interval {
+		return poller.interval
+	}
+
+	if delay *= 2; delay > poller.maxBackoff {
+		return poller.maxBackoff
+	}
+
+	return delay
+}
@@ -0,0 +1,58 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoller(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		poller      = NewPoller(time.Millisecond, 4*time.Millisecond)
+		polls       = 0
+		errs        = make([]error, 0)
+		pollErr     = errors.New("node unreachable")
+	)
+
+	defer cancel()
+
+	// the first poll asks for another one right away, the next three fail and the
+	// last one stops polling
+	poller.Poll(ctx, func() (bool, error) {
+		polls++
+
+		switch {
+		case polls == 1:
+			return true, nil
+		case polls <= 4:
+			return false, pollErr
+		}
+
+		cancel()
+
+		return false, nil
+	}, func(err error) {
+		errs = append(errs, err)
+	})
+
+	assert.Equal(t, 5, polls)
+	assert.Equal(t, []error{pollErr, pollErr, pollErr}, errs)
+}
+
+func TestPollerBackoff(t *testing.T) {
+	var (
+		poller = NewPoller(time.Second, 5*time.Second)
+	)
+
+	assert.Equal(t, time.Second, poller.backoff(0))
+	assert.Equal(t, 2*time.Second, poller.backoff(time.Second))
+	assert.Equal(t, 4*time.Second, poller.backoff(2*time.Second))
+	assert.Equal(t, 5*time.Second, poller.backoff(4*time.Second))
+
+	// a maximum below the interval is raised to the interval
+	assert.Equal(t, time.Second, NewPoller(time.Second, time.Millisecond).backoff(time.Second))
+}
@@ -0,0 +1,105 @@
+package util
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// MaxDecimals is the largest number of decimals accepted when scaling amounts,
+// which is far more than any ERC-20 token uses.
+const MaxDecimals = 77
+
+// ParseUnits parses a decimal string in whole token units, e.g. "12.5", into an
+// Amount in base units of a token with the given number of decimals. Values with
+// more fractional digits than the token has decimals are rejected rather than
+// rounded.
+func ParseUnits(value string, decimals int) (*Amount, error) {
+	var (
+		trimmed         = strings.TrimSpace(value)
+		negative        = strings.HasPrefix(trimmed, "-")
+		whole, fraction string
+		hasPoint        bool
+		parsed          *big.Int
+		ok              bool
+	)
+
+	if decimals < 0 || decimals > MaxDecimals {
+		return nil, fmt.Errorf("invalid number of decimals: %d", decimals)
+	}
+
+	if negative {
+		trimmed = trimmed[1:]
+	}
+
+	if index := strings.IndexByte(trimmed, '.'); index >= 0 {
+		whole, fraction, hasPoint = trimmed[:index], trimmed[index+1:], true
+	} else {
+		whole = trimmed
+	}
+
+	if (whole == "" && fraction == "") || (hasPoint && fraction == "") || !isDigits(whole) || !isDigits(fraction) {
+		return nil, fmt.Errorf("invalid amount: %q", value)
+	}
+
+	if len(fraction) > decimals {
+		return nil, fmt.Errorf("amount %q has more than %d decimals", value, decimals)
+	}
+
+	digits := whole + fraction + strings.Repeat("0", decimals-len(fraction))
+
+	if parsed, ok = new(big.Int).SetString(digits, 10); !ok {
+		return nil, fmt.Errorf("invalid amount: %q", value)
+	}
+
+	if negative {
+		parsed.Neg(parsed)
+	}
+
+	return NewAmountFromBig(parsed), nil
+}
+
+// FormatUnits formats an Amount in base units of a token with the given number
+// of decimals as a decimal string in whole token units without trailing zeros,
+// e.g. "12.5".
+func FormatUnits(amount *Amount, decimals int) string {
+	var (
+		value    = amount.Big()
+		negative = value.Sign() < 0
+		digits   = new(big.Int).Abs(value).String()
+	)
+
+	if decimals <= 0 {
+		return value.String()
+	}
+
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	var (
+		whole    = digits[:len(digits)-decimals]
+		fraction = strings.TrimRight(digits[len(digits)-decimals:], "0")
+		result   = whole
+	)
+
+	if fraction != "" {
+		result += "." + fraction
+	}
+
+	if negative {
+		result = "-" + result
+	}
+
+	return result
+}
+
+func isDigits(value string) bool {
+	for _, char := range value {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+
+	return true
+}
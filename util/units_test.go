@@ -0,0 +1,164 @@
+package util
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUnits(t *testing.T) {
+	var (
+		twelveAndAHalfDAI, _ = new(big.Int).SetString("12500000000000000000", 10)
+	)
+
+	type testcase struct {
+		name           string
+		value          string
+		decimals       int
+		expectedAmount *Amount
+		expectedError  error
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:           "fractional amount",
+			value:          "12.5",
+			decimals:       18,
+			expectedAmount: NewAmountFromBig(twelveAndAHalfDAI),
+		},
+		testcase{
+			name:           "whole amount",
+			value:          "3",
+			decimals:       6,
+			expectedAmount: NewAmount(3000000),
+		},
+		testcase{
+			name:           "leading decimal point",
+			value:          ".25",
+			decimals:       2,
+			expectedAmount: NewAmount(25),
+		},
+		testcase{
+			name:           "zero decimals",
+			value:          "42",
+			decimals:       0,
+			expectedAmount: NewAmount(42),
+		},
+		testcase{
+			name:           "zero",
+			value:          "0.0",
+			decimals:       18,
+			expectedAmount: NewAmount(0),
+		},
+		testcase{
+			name:           "negative amount",
+			value:          "-1.5",
+			decimals:       1,
+			expectedAmount: NewAmount(-15),
+		},
+		testcase{
+			name:          "too many decimals",
+			value:         "1.234",
+			decimals:      2,
+			expectedError: errors.New(`amount "1.234" has more than 2 decimals`),
+		},
+		testcase{
+			name:          "trailing decimal point",
+			value:         "1.",
+			decimals:      2,
+			expectedError: errors.New(`invalid amount: "1."`),
+		},
+		testcase{
+			name:          "not a number",
+			value:         "1e18",
+			decimals:      18,
+			expectedError: errors.New(`invalid amount: "1e18"`),
+		},
+		testcase{
+			name:          "empty",
+			value:         "",
+			decimals:      18,
+			expectedError: errors.New(`invalid amount: ""`),
+		},
+		testcase{
+			name:          "invalid decimals",
+			value:         "1",
+			decimals:      -1,
+			expectedError: errors.New("invalid number of decimals: -1"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			amount, err := ParseUnits(tc.value, tc.decimals)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedAmount, amount)
+		})
+	}
+}
+
+func TestFormatUnits(t *testing.T) {
+	var (
+		twelveAndAHalfDAI, _ = new(big.Int).SetString("12500000000000000000", 10)
+	)
+
+	type testcase struct {
+		name          string
+		amount        *Amount
+		decimals      int
+		expectedValue string
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:          "fractional amount",
+			amount:        NewAmountFromBig(twelveAndAHalfDAI),
+			decimals:      18,
+			expectedValue: "12.5",
+		},
+		testcase{
+			name:          "whole amount",
+			amount:        NewAmount(3000000),
+			decimals:      6,
+			expectedValue: "3",
+		},
+		testcase{
+			name:          "less than one token",
+			amount:        NewAmount(25),
+			decimals:      4,
+			expectedValue: "0.0025",
+		},
+		testcase{
+			name:          "zero decimals",
+			amount:        NewAmount(42),
+			decimals:      0,
+			expectedValue: "42",
+		},
+		testcase{
+			name:          "nil amount",
+			amount:        nil,
+			decimals:      18,
+			expectedValue: "0",
+		},
+		testcase{
+			name:          "negative amount",
+			amount:        NewAmount(-15),
+			decimals:      1,
+			expectedValue: "-1.5",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedValue, FormatUnits(tc.amount, tc.decimals))
+		})
+	}
+}